@@ -0,0 +1,350 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+var (
+	ErrNoCacheDir  = errors.New("unable to determine the user cache directory")
+	ErrSaveCache   = errors.New("unable to save the cache file")
+	ErrRemoveCache = errors.New("unable to remove the cache file")
+)
+
+// The version of the cache file structure which must be incremented whenever it changes.
+const formatVersion = 1
+
+// A cached project along with the details of the file it was parsed from.
+type Entry struct {
+	Size    int64          // size of the project file in bytes
+	ModTime time.Time      // modification time of the project file
+	Hash    string         // SHA-256 hash of the project file contents
+	Project parser.Project // details parsed from the project file
+}
+
+// The structure of the cache file on disk.
+type cacheFile struct {
+	FormatVersion int                  `json:"format_version"` // version of the file structure
+	ParserVersion int                  `json:"parser_version"` // parser version of all entries
+	Entries       map[string]fileEntry `json:"entries"`        // entries by absolute project path
+}
+
+// The structure of a cache entry on disk.  Projects often contain hundreds of plugin instances so
+// each occurrence refers to a plugin by its index instead of repeating the plugin's GUID and name.
+type fileEntry struct {
+	Size        int64            `json:"size"`        // size of the project file in bytes
+	ModTime     time.Time        `json:"mod_time"`    // modification time of the project file
+	Hash        string           `json:"hash"`        // SHA-256 hash of the project file contents
+	Metadata    parser.Metadata  `json:"metadata"`    // metadata of the Cubase version used
+	Plugins     []parser.Plugin  `json:"plugins"`     // unique plugins in order of appearance
+	Occurrences []fileOccurrence `json:"occurrences"` // every plugin instance in order of offset
+}
+
+// The structure of a plugin occurrence on disk.
+type fileOccurrence struct {
+	Plugin     int         `json:"plugin"`                // index of the plugin in the entry
+	Offset     int         `json:"offset"`                // byte offset of the plugin in the file
+	TrackTitle string      `json:"track_title,omitempty"` // title of a renamed instrument track
+	Role       parser.Role `json:"role,omitempty"`        // role inferred for the plugin if known
+}
+
+// Stores parsed projects on disk so that unchanged projects don't need to be read and parsed
+// again.  A cache is safe for concurrent use.
+type Cache struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]Entry
+	dirty   bool
+}
+
+// DefaultPath returns the path of the cache file in the user cache directory.
+func DefaultPath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", ErrNoCacheDir
+	}
+
+	return filepath.Join(cacheDir, "cubase-project-plugins", "cache.json"), nil
+}
+
+// Load loads the cache file at the path provided.  An empty cache is returned if the file doesn't
+// exist, can't be decoded or was created with a different file structure or parser version.
+func Load(path string) *Cache {
+	c := &Cache{path: path, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil ||
+		file.FormatVersion != formatVersion ||
+		file.ParserVersion != parser.Version {
+		c.dirty = true
+		return c
+	}
+
+	for entryPath, diskEntry := range file.Entries {
+		entry, ok := diskEntry.entry()
+		if !ok {
+			c.entries = make(map[string]Entry)
+			c.dirty = true
+			return c
+		}
+
+		c.entries[entryPath] = entry
+	}
+
+	return c
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Get returns the cached project for the path provided if the size and modification time of the
+// file match those of the cached entry.
+func (c *Cache) Get(path string, info fs.FileInfo) (*parser.Project, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key(path)]
+	if !ok || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+		return nil, false
+	}
+
+	return cloneProject(entry.Project), true
+}
+
+// GetByHash returns the cached project for the path provided if the hash of the file contents
+// matches that of the cached entry.  This allows files which have been touched but not changed
+// to be reused, in which case the size and modification time of the entry are updated.
+func (c *Cache) GetByHash(path string, info fs.FileInfo, hash string) (*parser.Project, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(path)
+
+	entry, ok := c.entries[k]
+	if !ok || entry.Hash != hash {
+		return nil, false
+	}
+
+	entry.Size = info.Size()
+	entry.ModTime = info.ModTime()
+	c.entries[k] = entry
+	c.dirty = true
+
+	return cloneProject(entry.Project), true
+}
+
+// Put stores the project parsed from the file at the path provided in the cache.
+func (c *Cache) Put(path string, info fs.FileInfo, hash string, project *parser.Project) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key(path)] = Entry{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Hash:    hash,
+		Project: *cloneProject(*project),
+	}
+	c.dirty = true
+}
+
+// Prune removes all entries whose files no longer exist or have changed since they were cached
+// and returns the number of entries removed.
+func (c *Cache) Prune() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+
+	for path, entry := range c.entries {
+		info, err := os.Stat(path)
+		if err != nil || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+			delete(c.entries, path)
+			removed++
+		}
+	}
+
+	if removed > 0 {
+		c.dirty = true
+	}
+
+	return removed
+}
+
+// Save writes the cache to disk if it has changed since it was loaded.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	file := cacheFile{
+		FormatVersion: formatVersion,
+		ParserVersion: parser.Version,
+		Entries:       make(map[string]fileEntry, len(c.entries)),
+	}
+	for path, entry := range c.entries {
+		file.Entries[path] = newFileEntry(entry)
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSaveCache, err)
+	}
+
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("%w: %w", ErrSaveCache, err)
+	}
+
+	c.dirty = false
+
+	return nil
+}
+
+// writeFileAtomic writes the data provided to a uniquely named temporary file alongside the path
+// provided and then renames it so that an interrupted save or another process saving at the same
+// time never leaves a partially written file behind.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Clear removes the cache file at the path provided.
+func Clear(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrRemoveCache, err)
+	}
+
+	return nil
+}
+
+// Hash returns the hash of the project file contents provided.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// key returns the key used to store the path provided in the cache.
+func key(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		return absPath
+	}
+
+	return path
+}
+
+// newFileEntry returns the on-disk structure of the entry provided.
+func newFileEntry(entry Entry) fileEntry {
+	indexes := make(map[parser.Plugin]int, len(entry.Project.Plugins))
+	for i, plugin := range entry.Project.Plugins {
+		indexes[plugin] = i
+	}
+
+	var occurrences []fileOccurrence
+	if entry.Project.Occurrences != nil {
+		occurrences = make([]fileOccurrence, 0, len(entry.Project.Occurrences))
+	}
+
+	for _, occurrence := range entry.Project.Occurrences {
+		occurrences = append(occurrences, fileOccurrence{
+			Plugin:     indexes[occurrence.Plugin],
+			Offset:     occurrence.Offset,
+			TrackTitle: occurrence.TrackTitle,
+			Role:       occurrence.Role,
+		})
+	}
+
+	return fileEntry{
+		Size:        entry.Size,
+		ModTime:     entry.ModTime,
+		Hash:        entry.Hash,
+		Metadata:    entry.Project.Metadata,
+		Plugins:     entry.Project.Plugins,
+		Occurrences: occurrences,
+	}
+}
+
+// entry returns the entry described by the on-disk structure or false if any of its occurrences
+// refer to a plugin which doesn't exist.
+func (e fileEntry) entry() (Entry, bool) {
+	var occurrences []parser.Occurrence
+	if e.Occurrences != nil {
+		occurrences = make([]parser.Occurrence, 0, len(e.Occurrences))
+	}
+
+	for _, occurrence := range e.Occurrences {
+		if occurrence.Plugin < 0 || occurrence.Plugin >= len(e.Plugins) {
+			return Entry{}, false
+		}
+
+		occurrences = append(occurrences, parser.Occurrence{
+			Plugin:     e.Plugins[occurrence.Plugin],
+			Offset:     occurrence.Offset,
+			TrackTitle: occurrence.TrackTitle,
+			Role:       occurrence.Role,
+		})
+	}
+
+	return Entry{
+		Size:    e.Size,
+		ModTime: e.ModTime,
+		Hash:    e.Hash,
+		Project: parser.Project{
+			Metadata:    e.Metadata,
+			Plugins:     e.Plugins,
+			Occurrences: occurrences,
+		},
+	}, true
+}
+
+// cloneProject returns a copy of the project provided which shares no memory with it.
+func cloneProject(project parser.Project) *parser.Project {
+	project.Plugins = slices.Clone(project.Plugins)
+	project.Occurrences = slices.Clone(project.Occurrences)
+
+	return &project
+}
@@ -0,0 +1,241 @@
+package cache_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/cache"
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+var testProject = parser.Project{
+	Metadata: parser.Metadata{
+		Application:  "Cubase",
+		Version:      "13.0.10",
+		ReleaseDate:  "Oct 10 2023",
+		Architecture: "WIN64",
+	},
+	Plugins: []parser.Plugin{
+		{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+		{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+	},
+	Occurrences: []parser.Occurrence{
+		{
+			Plugin: parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+			Offset: 1024,
+			Role:   parser.RoleInstrument,
+		},
+		{
+			Plugin:     parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+			Offset:     2048,
+			TrackTitle: "Pads",
+			Role:       parser.RoleInstrument,
+		},
+		{
+			Plugin: parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+			Offset: 4096,
+			Role:   parser.RoleMaster,
+		},
+	},
+}
+
+func writeProjectFile(t *testing.T, dir string, contents string) (string, os.FileInfo) {
+	t.Helper()
+
+	path := filepath.Join(dir, "Project.cpr")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	return path, info
+}
+
+func TestCacheSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache", "cache.json")
+	projectPath, info := writeProjectFile(t, dir, "project")
+
+	c := cache.Load(cachePath)
+	require.Equal(t, 0, c.Len())
+
+	c.Put(projectPath, info, cache.Hash([]byte("project")), &testProject)
+	require.NoError(t, c.Save())
+
+	c = cache.Load(cachePath)
+	require.Equal(t, 1, c.Len())
+
+	project, ok := c.Get(projectPath, info)
+	require.True(t, ok)
+	require.Equal(t, testProject, *project)
+
+	// Plugins are only stored once per entry rather than once per occurrence.
+	data, err := os.ReadFile(cachePath)
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(data), "565354416D62726F6D6E697370686572"))
+
+	// The temporary file used while saving is always renamed over the cache file.
+	files, err := os.ReadDir(filepath.Dir(cachePath))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestCacheSaveConcurrent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache.json")
+	projectPath, info := writeProjectFile(t, dir, "project")
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			c := cache.Load(cachePath)
+			c.Put(projectPath, info, cache.Hash([]byte("project")), &testProject)
+			require.NoError(t, c.Save())
+		}()
+	}
+	wg.Wait()
+
+	project, ok := cache.Load(cachePath).Get(projectPath, info)
+	require.True(t, ok)
+	require.Equal(t, testProject, *project)
+}
+
+func TestCacheGetChangedFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	projectPath, info := writeProjectFile(t, dir, "project")
+
+	c := cache.Load(filepath.Join(dir, "cache.json"))
+	c.Put(projectPath, info, cache.Hash([]byte("project")), &testProject)
+
+	modTime := info.ModTime().Add(time.Hour)
+	require.NoError(t, os.Chtimes(projectPath, modTime, modTime))
+
+	touchedInfo, err := os.Stat(projectPath)
+	require.NoError(t, err)
+
+	_, ok := c.Get(projectPath, touchedInfo)
+	require.False(t, ok)
+
+	_, ok = c.GetByHash(projectPath, touchedInfo, cache.Hash([]byte("changed")))
+	require.False(t, ok)
+
+	project, ok := c.GetByHash(projectPath, touchedInfo, cache.Hash([]byte("project")))
+	require.True(t, ok)
+	require.Equal(t, testProject, *project)
+
+	// The entry is updated with the new modification time after a successful hash lookup.
+	_, ok = c.Get(projectPath, touchedInfo)
+	require.True(t, ok)
+}
+
+func TestCacheLoadFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		formatVersion int
+		parserVersion int
+		plugin        int
+		loaded        bool
+	}{
+		{name: "current", formatVersion: 1, parserVersion: parser.Version, loaded: true},
+		{name: "different format version", formatVersion: 0, parserVersion: parser.Version},
+		{name: "different parser version", formatVersion: 1, parserVersion: parser.Version + 1},
+		{name: "unknown plugin", formatVersion: 1, parserVersion: parser.Version, plugin: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			cachePath := filepath.Join(dir, "cache.json")
+			projectPath, info := writeProjectFile(t, dir, "project")
+
+			data, err := json.Marshal(map[string]any{
+				"format_version": tc.formatVersion,
+				"parser_version": tc.parserVersion,
+				"entries": map[string]any{
+					projectPath: map[string]any{
+						"size":        info.Size(),
+						"mod_time":    info.ModTime(),
+						"hash":        cache.Hash([]byte("project")),
+						"metadata":    testProject.Metadata,
+						"plugins":     testProject.Plugins,
+						"occurrences": []map[string]any{{"plugin": tc.plugin, "offset": 1024}},
+					},
+				},
+			})
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(cachePath, data, 0o644))
+
+			c := cache.Load(cachePath)
+			_, ok := c.Get(projectPath, info)
+
+			require.Equal(t, tc.loaded, ok)
+			require.Equal(t, tc.loaded, c.Len() == 1)
+		})
+	}
+}
+
+func TestCacheLoadCorrupt(t *testing.T) {
+	t.Parallel()
+
+	cachePath := filepath.Join(t.TempDir(), "cache.json")
+	require.NoError(t, os.WriteFile(cachePath, []byte("{"), 0o644))
+
+	c := cache.Load(cachePath)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestCachePrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	projectPath, info := writeProjectFile(t, dir, "project")
+	missingPath := filepath.Join(dir, "Missing.cpr")
+
+	c := cache.Load(filepath.Join(dir, "cache.json"))
+	c.Put(projectPath, info, cache.Hash([]byte("project")), &testProject)
+	c.Put(missingPath, info, cache.Hash([]byte("project")), &testProject)
+
+	require.Equal(t, 1, c.Prune())
+	require.Equal(t, 1, c.Len())
+
+	_, ok := c.Get(projectPath, info)
+	require.True(t, ok)
+}
+
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cachePath := filepath.Join(dir, "cache.json")
+	projectPath, info := writeProjectFile(t, dir, "project")
+
+	c := cache.Load(cachePath)
+	c.Put(projectPath, info, cache.Hash([]byte("project")), &testProject)
+	require.NoError(t, c.Save())
+
+	require.NoError(t, cache.Clear(cachePath))
+	require.NoFileExists(t, cachePath)
+
+	// Clearing a cache which doesn't exist is not an error.
+	require.NoError(t, cache.Clear(cachePath))
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/cache"
+)
+
+var noCache bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages the cache of parsed projects.",
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Removes cached projects which no longer exist or have changed.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cmd.SilenceUsage = true
+
+		cachePath, err := cache.DefaultPath()
+		if err != nil {
+			return err
+		}
+
+		projectCache := cache.Load(cachePath)
+		removed := projectCache.Prune()
+
+		if err := projectCache.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Removed %d of %d cached projects\n", removed, removed+projectCache.Len())
+
+		return nil
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Removes all cached projects.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cmd.SilenceUsage = true
+
+		cachePath, err := cache.DefaultPath()
+		if err != nil {
+			return err
+		}
+
+		return cache.Clear(cachePath)
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cachePruneCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// openCache loads the cache of parsed projects, returning nil when caching has been disabled or
+// the cache location can't be determined.
+func openCache() *cache.Cache {
+	if noCache {
+		return nil
+	}
+
+	cachePath, err := cache.DefaultPath()
+	if err != nil {
+		return nil
+	}
+
+	return cache.Load(cachePath)
+}
+
+// saveCache saves the cache of parsed projects if caching is enabled.  Failing to save the cache
+// doesn't affect the scan so the error is only reported as a warning.
+func saveCache(projectCache *cache.Cache) {
+	if projectCache == nil {
+		return
+	}
+
+	if err := projectCache.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/policy"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var ErrPolicyViolations = errors.New("one or more projects violate the plugin policy")
+
+var (
+	checkJUnitPath  string
+	checkMinVersion string
+	checkMaxVersion string
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [flags] [project path]...",
+	Short: "Checks that projects only use plugins permitted by the policy in the config file.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		if checkMinVersion != "" {
+			config.Policy.MinVersion = checkMinVersion
+		}
+
+		if checkMaxVersion != "" {
+			config.Policy.MaxVersion = checkMaxVersion
+		}
+
+		checker, err := policy.NewChecker(config)
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		var checks []projectCheck
+
+		scanner := newScanner(policy.ScanConfig(config), projectCache)
+		scanner.OnResult = func(result scan.Result) error {
+			checks = append(
+				checks, projectCheck{Path: result.Path, Violations: checker.Check(result)},
+			)
+			return nil
+		}
+
+		report, err := scanner.Scan(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+
+		heading := color.New(color.BgRed, color.FgHiWhite)
+
+		violations := printPolicyViolations(checks)
+		printFailures(os.Stdout, heading, report.Failures)
+		printWarnings(os.Stdout, heading, report.Warnings)
+
+		if checkJUnitPath != "" {
+			if err := writeJUnitReport(checkJUnitPath, checks, report.Failures); err != nil {
+				return err
+			}
+		}
+
+		var violationsErr error
+		if violations > 0 {
+			violationsErr = fmt.Errorf("%w (%d violations)", ErrPolicyViolations, violations)
+		}
+
+		return errors.Join(violationsErr, scanError(report.Failures, report.Warnings))
+	},
+}
+
+func init() {
+	checkCmd.Flags().
+		StringVar(&checkJUnitPath, "junit", "", "write a JUnit XML report to the `path` provided")
+	checkCmd.Flags().
+		StringVar(
+			&checkMinVersion, "min-version", "", "oldest Cubase `version` permitted for projects",
+		)
+	checkCmd.Flags().
+		StringVar(
+			&checkMaxVersion, "max-version", "", "newest Cubase `version` permitted for projects",
+		)
+
+	addScanFlags(checkCmd)
+	addStrictFlag(checkCmd)
+	rootCmd.AddCommand(checkCmd)
+}
+
+// A project along with the policy violations found in it.
+type projectCheck struct {
+	Path       string             // path to the project file
+	Violations []policy.Violation // violations of the policy by plugins in the project
+}
+
+// printPolicyViolations prints the violations found in each project followed by a summary line
+// and returns the total number of violations.
+func printPolicyViolations(checks []projectCheck) int {
+	heading := color.New(color.BgRed, color.FgHiWhite)
+	subHeading := color.New(color.FgHiBlue)
+
+	violations := 0
+	violatingProjects := 0
+
+	for _, check := range checks {
+		if len(check.Violations) == 0 {
+			continue
+		}
+
+		violations += len(check.Violations)
+		violatingProjects++
+
+		fmt.Println()
+		heading.Printf("Path: %s", check.Path)
+		fmt.Println()
+		fmt.Println()
+
+		for _, violation := range check.Violations {
+			fmt.Printf("    > %s\n", describeViolation(violation))
+		}
+	}
+
+	fmt.Println()
+	subHeading.Printf(
+		"Checked %d projects and found %d violations in %d projects",
+		len(checks),
+		violations,
+		violatingProjects,
+	)
+	fmt.Println()
+
+	return violations
+}
+
+// describeViolation returns a description of the violation provided which includes the plugin
+// responsible when there is one.
+func describeViolation(violation policy.Violation) string {
+	if violation.Plugin == nil {
+		return violation.Detail
+	}
+
+	return fmt.Sprintf(
+		"%s : %s : %s (%s)",
+		violation.Plugin.GUID,
+		formatLabel(violation.Plugin.Format()),
+		violation.Plugin.Name,
+		violation.Detail,
+	)
+}
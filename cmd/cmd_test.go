@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+var testDataPath = filepath.Join("..", "parser", "testdata")
+
+// The projects scanned by formatter tests, which include a 32-bit project, a 64-bit project, a
+// project which can't be parsed and a path which can't be walked.
+var testProjectPaths = []string{
+	filepath.Join(testDataPath, "Example Project (Cubase 5 32-bit).cpr"),
+	filepath.Join(testDataPath, "Example Project (Cubase 13).cpr"),
+	filepath.Join(testDataPath, "Truncated Project (Version).cpr"),
+	filepath.Join(testDataPath, "Missing Project.cpr"),
+}
+
+func testConfig() config.Config {
+	return config.Config{
+		Projects: config.Projects{
+			Report32Bit: true,
+			Report64Bit: true,
+		},
+	}
+}
+
+// scanTestProjects scans the test projects without any callbacks.
+func scanTestProjects(t *testing.T) *scan.Report {
+	t.Helper()
+
+	report, err := scan.NewScanner(testConfig()).Scan(context.Background(), testProjectPaths)
+	require.NoError(t, err)
+
+	return report
+}
+
+// renderFormatter scans the test projects using the formatter provided in the same way as the
+// root command.
+func renderFormatter(t *testing.T, out formatter) {
+	t.Helper()
+
+	scanner := scan.NewScanner(testConfig())
+	scanner.OnResult = out.Project
+	scanner.OnFailure = out.ProjectError
+	scanner.OnWarning = out.Warning
+
+	report, err := scanner.Scan(context.Background(), testProjectPaths)
+	require.NoError(t, err)
+	require.NoError(t, out.Summary(report.Summary))
+}
+
+// requireGolden compares the output provided with the golden file of the name provided, updating
+// the golden file instead when the -update flag is used.
+func requireGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, output, 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(output))
+}
+
+// renderGolden renders the test projects using the formatter for the format provided and
+// compares the output with the golden file of the name provided.
+func renderGolden(t *testing.T, name, format string, options outputOptions) {
+	t.Helper()
+
+	var b bytes.Buffer
+	out, err := newFormatter(format, &b, options)
+	require.NoError(t, err)
+
+	renderFormatter(t, out)
+	requireGolden(t, name, b.Bytes())
+}
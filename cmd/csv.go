@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// Renders scan results as delimited text with one row per plugin used in each project.
+type csvFormatter struct {
+	stderrProblems
+
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVFormatter(w io.Writer, comma rune) *csvFormatter {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	return &csvFormatter{w: writer}
+}
+
+func (f *csvFormatter) Project(result scan.Result) error {
+	if err := f.writeHeader(); err != nil {
+		return err
+	}
+
+	for _, plugin := range result.Plugins {
+		err := f.w.Write([]string{
+			result.Path,
+			result.Project.Metadata.Version,
+			result.Project.Metadata.Architecture,
+			plugin.GUID,
+			plugin.Name,
+			string(plugin.Format()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	f.w.Flush()
+
+	return f.w.Error()
+}
+
+func (f *csvFormatter) Summary(_ scan.Summary) error {
+	if err := f.writeHeader(); err != nil {
+		return err
+	}
+
+	f.w.Flush()
+
+	return f.w.Error()
+}
+
+func (f *csvFormatter) writeHeader() error {
+	if f.headerWritten {
+		return nil
+	}
+
+	f.headerWritten = true
+
+	return f.w.Write([]string{"path", "version", "architecture", "guid", "name", "format"})
+}
+
+// writeSummaryCSV writes the plugin usage summary as CSV to the path provided with one row per
+// plugin containing its usage counts by project architecture.
+func writeSummaryCSV(path string, summary scan.Summary) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create the summary CSV file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+
+	err = w.Write([]string{
+		"guid", "name", "count_32_bit", "count_64_bit", "count_total", "format",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, plugin := range parser.SortedPlugins(summary.PluginCounts) {
+		err := w.Write([]string{
+			plugin.GUID,
+			plugin.Name,
+			strconv.Itoa(summary.PluginCounts32[plugin]),
+			strconv.Itoa(summary.PluginCounts64[plugin]),
+			strconv.Itoa(summary.PluginCounts[plugin]),
+			string(plugin.Format()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+func TestCSVFormatter(t *testing.T) {
+	t.Parallel()
+
+	renderGolden(t, "csv", FormatCSV, outputOptions{})
+}
+
+func TestTSVFormatter(t *testing.T) {
+	t.Parallel()
+
+	renderGolden(t, "tsv", FormatTSV, outputOptions{})
+}
+
+func TestCSVFormatterQuoting(t *testing.T) {
+	t.Parallel()
+
+	projectBytes, err := os.ReadFile(filepath.Join(testDataPath, "Example Project (Cubase 13).cpr"))
+	require.NoError(t, err)
+
+	projectPath := filepath.Join(t.TempDir(), `Song, "Final".cpr`)
+	require.NoError(t, os.WriteFile(projectPath, projectBytes, 0o644))
+
+	var b bytes.Buffer
+	out := newCSVFormatter(&b, ',')
+
+	scanner := scan.NewScanner(testConfig())
+	scanner.OnResult = out.Project
+	report, err := scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+	require.NoError(t, out.Summary(report.Summary))
+
+	require.Contains(t, b.String(), `Song, ""Final"".cpr"`)
+
+	records, err := csv.NewReader(&b).ReadAll()
+	require.NoError(t, err)
+	require.Equal(
+		t, []string{"path", "version", "architecture", "guid", "name", "format"}, records[0],
+	)
+	require.Len(t, records, len(report.Results[0].Plugins)+1)
+	for _, record := range records[1:] {
+		require.Equal(t, projectPath, record[0])
+	}
+}
+
+func TestCSVFormatterNoProjects(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	require.NoError(t, newCSVFormatter(&b, ',').Summary(scan.NewSummary()))
+	require.Equal(t, "path,version,architecture,guid,name,format\n", b.String())
+}
+
+func TestWriteSummaryCSV(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "summary.csv")
+	require.NoError(t, writeSummaryCSV(path, scanTestProjects(t).Summary))
+
+	output, err := os.ReadFile(path)
+	require.NoError(t, err)
+	requireGolden(t, "summary-csv", output)
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/diff"
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+var diffFormat string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [flags] <old project> <new project>",
+	Short: "Compares the plugins and Cubase version used by two projects.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if diffFormat != FormatText && diffFormat != FormatJSON {
+			return fmt.Errorf("%w: %s", ErrUnknownFormat, diffFormat)
+		}
+
+		oldProject, err := parseProjectFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		newProject, err := parseProjectFile(args[1])
+		if err != nil {
+			return err
+		}
+
+		projectDiff := diff.Projects(oldProject, newProject)
+
+		if diffFormat == FormatJSON {
+			return writeJSONProjectDiff(os.Stdout, args[0], args[1], projectDiff)
+		}
+
+		printProjectDiff(os.Stdout, args[0], args[1], projectDiff)
+
+		return nil
+	},
+}
+
+func init() {
+	diffCmd.Flags().
+		StringVarP(&diffFormat, "format", "f", FormatText, "output `format` (text or json)")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+// parseProjectFile reads and parses the project at the path provided.
+func parseProjectFile(path string) (*parser.Project, error) {
+	projectBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := parser.NewReader(projectBytes)
+
+	project, err := reader.GetProjectDetails()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return project, nil
+}
+
+// The JSON document produced when comparing two projects.
+type jsonProjectDiff struct {
+	SchemaVersion int    `json:"schema_version"` // version of the document structure
+	OldPath       string `json:"old_path"`       // path to the old project file
+	NewPath       string `json:"new_path"`       // path to the new project file
+	diff.ProjectDiff
+}
+
+func writeJSONProjectDiff(w io.Writer, oldPath, newPath string, d diff.ProjectDiff) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(jsonProjectDiff{
+		SchemaVersion: JSONSchemaVersion,
+		OldPath:       oldPath,
+		NewPath:       newPath,
+		ProjectDiff:   d,
+	})
+}
+
+func printProjectDiff(w io.Writer, oldPath, newPath string, d diff.ProjectDiff) {
+	heading := color.New(color.BgRed, color.FgHiWhite)
+	subHeading := color.New(color.FgHiBlue)
+
+	fmt.Fprintln(w)
+	heading.Fprintf(w, "Old: %s", oldPath)
+	fmt.Fprintln(w)
+	heading.Fprintf(w, "New: %s", newPath)
+	fmt.Fprintln(w)
+
+	if !d.HasChanges() {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "The projects use the same Cubase version and plugins.")
+	}
+
+	printDiffSection(w, subHeading, "Metadata Changes", len(d.Metadata), func() {
+		for _, change := range d.Metadata {
+			fmt.Fprintf(w, "    ~ %s : %s -> %s\n", change.Field, change.Old, change.New)
+		}
+	})
+	printDiffSection(w, subHeading, "Added Plugins", len(d.Added), func() {
+		for _, plugin := range d.Added {
+			fmt.Fprintf(
+				w, "    + %s : %s : %s\n", plugin.GUID, formatLabel(plugin.Format), plugin.Name,
+			)
+		}
+	})
+	printDiffSection(w, subHeading, "Removed Plugins", len(d.Removed), func() {
+		for _, plugin := range d.Removed {
+			fmt.Fprintf(
+				w, "    - %s : %s : %s\n", plugin.GUID, formatLabel(plugin.Format), plugin.Name,
+			)
+		}
+	})
+	printDiffSection(w, subHeading, "Renamed Plugins", len(d.Renamed), func() {
+		for _, rename := range d.Renamed {
+			fmt.Fprintf(
+				w,
+				"    ~ %s : %s : %s -> %s\n",
+				rename.GUID,
+				formatLabel(rename.Format),
+				rename.OldName,
+				rename.NewName,
+			)
+		}
+	})
+	printDiffSection(w, subHeading, "Unchanged Plugins", len(d.Unchanged), func() {
+		for _, plugin := range d.Unchanged {
+			fmt.Fprintf(
+				w, "    = %s : %s : %s\n", plugin.GUID, formatLabel(plugin.Format), plugin.Name,
+			)
+		}
+	})
+}
+
+// printDiffSection prints a heading followed by the lines printed by the function provided,
+// skipping sections which have nothing to display.
+func printDiffSection(w io.Writer, subHeading *color.Color, title string, count int, lines func()) {
+	if count == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	subHeading.Fprintf(w, "%s (%d)", title, count)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	lines()
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// The number of plugins displayed in the top plugins chart of the HTML report.
+const reportTopPlugins = 20
+
+var (
+	//go:embed report/report.html.tmpl
+	reportTemplateText string
+
+	//go:embed report/report.css
+	reportCSS string
+
+	//go:embed report/report.js
+	reportJS string
+
+	reportTemplate = template.Must(
+		template.New("report").
+			Funcs(template.FuncMap{"formatLabel": formatLabel, "versionSortKey": versionSortKey}).
+			Parse(reportTemplateText),
+	)
+)
+
+// The data model used to render the HTML report.
+type reportData struct {
+	CSS        template.CSS       // stylesheet embedded in the report
+	JS         template.JS        // script embedded in the report
+	Projects   []scan.Result      // all projects scanned
+	Plugins    []scan.PluginCount // plugin usage by project architecture
+	TopPlugins []reportBar        // bars for the most used plugins chart
+	Versions   []reportBar        // bars for the projects per Cubase version chart
+}
+
+// A single bar in a bar chart.
+type reportBar struct {
+	Label   string // label displayed alongside the bar
+	Count   int    // value represented by the bar
+	Percent int    // width of the bar relative to the largest bar in the chart
+}
+
+// Renders all scan results as a self-contained HTML report once scanning has completed.
+type htmlFormatter struct {
+	stderrProblems
+
+	w       io.Writer
+	results []scan.Result
+}
+
+func newHTMLFormatter(w io.Writer) *htmlFormatter {
+	return &htmlFormatter{w: w}
+}
+
+func (f *htmlFormatter) Project(result scan.Result) error {
+	f.results = append(f.results, result)
+	return nil
+}
+
+func (f *htmlFormatter) Summary(summary scan.Summary) error {
+	topPlugins := matrixPlugins(summary.PluginCounts, reportTopPlugins)
+	labels := pluginLabels(topPlugins)
+
+	topPluginBars := make([]reportBar, 0, len(topPlugins))
+	for _, plugin := range topPlugins {
+		topPluginBars = append(topPluginBars, reportBar{
+			Label: labels[plugin],
+			Count: summary.PluginCounts[plugin],
+		})
+	}
+
+	return reportTemplate.Execute(f.w, reportData{
+		CSS:        template.CSS(reportCSS), //nolint:gosec // embedded at build time
+		JS:         template.JS(reportJS),   //nolint:gosec // embedded at build time
+		Projects:   f.results,
+		Plugins:    summary.SortedPluginCounts(),
+		TopPlugins: scaleReportBars(topPluginBars),
+		Versions:   scaleReportBars(f.versionBars()),
+	})
+}
+
+// versionBars returns a bar for each major Cubase version containing the number of projects
+// created with that version.
+func (f *htmlFormatter) versionBars() []reportBar {
+	type version struct {
+		application string
+		major       int
+	}
+
+	versionCounts := make(map[version]int)
+	for _, result := range f.results {
+		metadata := result.Project.Metadata
+		major, _, _ := strings.Cut(metadata.Version, ".")
+		majorNumber, _ := strconv.Atoi(major)
+		versionCounts[version{application: metadata.Application, major: majorNumber}]++
+	}
+
+	versions := make([]version, 0, len(versionCounts))
+	for v := range versionCounts {
+		versions = append(versions, v)
+	}
+
+	slices.SortFunc(versions, func(a, b version) int {
+		return cmp.Or(cmp.Compare(a.application, b.application), cmp.Compare(a.major, b.major))
+	})
+
+	bars := make([]reportBar, 0, len(versions))
+	for _, v := range versions {
+		bars = append(bars, reportBar{
+			Label: fmt.Sprintf("%s %d", v.application, v.major),
+			Count: versionCounts[v],
+		})
+	}
+
+	return bars
+}
+
+// scaleReportBars sets the width of each bar provided relative to the largest bar.
+func scaleReportBars(bars []reportBar) []reportBar {
+	largest := 0
+	for _, bar := range bars {
+		largest = max(largest, bar.Count)
+	}
+
+	for i := range bars {
+		if largest > 0 {
+			bars[i].Percent = bars[i].Count * 100 / largest
+		}
+	}
+
+	return bars
+}
+
+// The number of digits each version component is padded to by versionSortKey.
+const versionSortKeyDigits = 4
+
+// versionSortKey returns a key which sorts Cubase versions numerically when the keys are compared
+// as text (e.g. "0013.0000.0010" for "13.0.10").  Versions which can't be parsed are returned
+// unchanged.
+func versionSortKey(version string) string {
+	v, err := parser.ParseAppVersion(version)
+	if err != nil {
+		return version
+	}
+
+	components := make([]string, 0, len(v.Components))
+	for _, component := range v.Components {
+		components = append(components, fmt.Sprintf("%0*d", versionSortKeyDigits, component))
+	}
+
+	return strings.Join(components, ".")
+}
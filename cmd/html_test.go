@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionSortKey(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "0013.0000.0010", versionSortKey("13.0.10"))
+	require.Equal(t, "0003", versionSortKey("SX3"))
+	require.Equal(t, "unknown", versionSortKey("unknown"))
+
+	versions := []string{"13.0.10", "4.5.2", "9.5.41", "12.0.70", "5.5.3"}
+	slices.SortFunc(versions, func(a, b string) int {
+		return cmp.Compare(versionSortKey(a), versionSortKey(b))
+	})
+	require.Equal(t, []string{"4.5.2", "5.5.3", "9.5.41", "12.0.70", "13.0.10"}, versions)
+}
+
+func TestHTMLFormatterVersionSortKey(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	renderFormatter(t, newHTMLFormatter(&b))
+
+	require.Contains(t, b.String(), `<td data-value="0013.0000.0010">13.0.10</td>`)
+	require.Contains(t, b.String(), `<td data-value="0005.0005.0003">5.5.3</td>`)
+}
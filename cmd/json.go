@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// JSONSchemaVersion is the version of the JSON document structure produced by the tool.  It must
+// be incremented whenever a field is removed, renamed or has its meaning changed.
+const JSONSchemaVersion = 1
+
+// The JSON document produced when using the JSON output format.
+type jsonDocument struct {
+	SchemaVersion int           `json:"schema_version"` // version of the document structure
+	Projects      []jsonProject `json:"projects"`       // all projects scanned
+	Summaries     jsonSummaries `json:"summaries"`      // plugin usage by project architecture
+	Failures      []jsonFailure `json:"failures"`       // projects which could not be parsed
+	Warnings      []jsonWarning `json:"warnings"`       // paths which could not be walked or read
+}
+
+// A project which could not be parsed.
+type jsonFailure struct {
+	Path  string `json:"path"`  // path to the project file
+	Error string `json:"error"` // description of the error which occurred
+}
+
+func newJSONFailure(failure scan.Failure) jsonFailure {
+	return jsonFailure{Path: failure.Path, Error: failure.Err.Error()}
+}
+
+// A path which could not be walked or read.
+type jsonWarning struct {
+	Path      string `json:"path"`      // path which could not be walked or read
+	Operation string `json:"operation"` // operation which failed ("walk" or "read")
+	Error     string `json:"error"`     // description of the error which occurred
+}
+
+func newJSONWarning(warning scan.Warning) jsonWarning {
+	return jsonWarning{Path: warning.Path, Operation: warning.Op, Error: warning.Err.Error()}
+}
+
+// A project along with the plugins reported for it.
+type jsonProject struct {
+	Path     string              `json:"path"`     // path to the project file
+	Metadata parser.Metadata     `json:"metadata"` // details about the Cubase version used
+	Plugins  []jsonProjectPlugin `json:"plugins"`  // plugins used after ignores are applied
+}
+
+// A plugin used in a project along with the number of instances of it in the project.
+type jsonProjectPlugin struct {
+	GUID      string        `json:"guid"`      // globally unique identifier for the plugin
+	Name      string        `json:"name"`      // name of the plugin
+	Format    parser.Format `json:"format"`    // format of the plugin (vst2 or vst3)
+	Instances int           `json:"instances"` // number of instances of the plugin in the project
+
+	TrackTitles []string      `json:"track_titles,omitempty"` // titles of renamed instrument tracks
+	Roles       []parser.Role `json:"roles"`                  // roles the plugin is used in
+}
+
+// The plugin usage summaries, equivalent to those displayed in the text output.
+type jsonSummaries struct {
+	Plugins32Bit []jsonPluginCount `json:"32_bit"` // plugins used in 32-bit projects
+	Plugins64Bit []jsonPluginCount `json:"64_bit"` // plugins used in 64-bit projects
+	PluginsAll   []jsonPluginCount `json:"all"`    // plugins used in all projects
+
+	PluginsByRole map[parser.Role][]jsonPluginCount `json:"by_role"` // plugins used in each role
+}
+
+// A plugin along with the number of projects it was used in.
+type jsonPluginCount struct {
+	GUID   string        `json:"guid"`   // globally unique identifier for the plugin
+	Name   string        `json:"name"`   // name of the plugin
+	Format parser.Format `json:"format"` // format of the plugin (vst2 or vst3)
+	Count  int           `json:"count"`  // number of projects using the plugin
+}
+
+// Renders all scan results as a single JSON document once scanning has completed.
+type jsonFormatter struct {
+	w        io.Writer
+	projects []jsonProject
+	failures []jsonFailure
+	warnings []jsonWarning
+}
+
+func newJSONFormatter(w io.Writer) *jsonFormatter {
+	return &jsonFormatter{
+		w:        w,
+		projects: []jsonProject{},
+		failures: []jsonFailure{},
+		warnings: []jsonWarning{},
+	}
+}
+
+func (f *jsonFormatter) Project(result scan.Result) error {
+	f.projects = append(f.projects, newJSONProject(result))
+	return nil
+}
+
+func (f *jsonFormatter) ProjectError(failure scan.Failure) error {
+	f.failures = append(f.failures, newJSONFailure(failure))
+	return nil
+}
+
+func (f *jsonFormatter) Warning(warning scan.Warning) error {
+	f.warnings = append(f.warnings, newJSONWarning(warning))
+	return nil
+}
+
+func (f *jsonFormatter) Summary(summary scan.Summary) error {
+	document := jsonDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Projects:      f.projects,
+		Summaries:     newJSONSummaries(summary),
+		Failures:      f.failures,
+		Warnings:      f.warnings,
+	}
+
+	encoder := json.NewEncoder(f.w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
+
+func newJSONProject(result scan.Result) jsonProject {
+	counts := result.Project.InstanceCounts()
+	trackTitles := result.Project.TrackTitles()
+	roles := result.Project.Roles()
+
+	plugins := make([]jsonProjectPlugin, 0, len(result.Plugins))
+	for _, plugin := range result.Plugins {
+		plugins = append(plugins, jsonProjectPlugin{
+			GUID:      plugin.GUID,
+			Name:      plugin.Name,
+			Format:    plugin.Format(),
+			Instances: counts[plugin],
+
+			TrackTitles: trackTitles[plugin],
+			Roles:       roleList(roles[plugin]),
+		})
+	}
+
+	return jsonProject{
+		Path:     result.Path,
+		Metadata: result.Project.Metadata,
+		Plugins:  plugins,
+	}
+}
+
+func newJSONSummaries(summary scan.Summary) jsonSummaries {
+	return jsonSummaries{
+		Plugins32Bit: newJSONPluginCounts(summary.PluginCounts32),
+		Plugins64Bit: newJSONPluginCounts(summary.PluginCounts64),
+		PluginsAll:   newJSONPluginCounts(summary.PluginCounts),
+
+		PluginsByRole: newJSONRoleCounts(summary.RoleCounts),
+	}
+}
+
+func newJSONRoleCounts(
+	roleCounts map[parser.Role]map[parser.Plugin]int,
+) map[parser.Role][]jsonPluginCount {
+	counts := make(map[parser.Role][]jsonPluginCount, len(parser.Roles))
+	for _, role := range parser.Roles {
+		counts[role] = newJSONPluginCounts(roleCounts[role])
+	}
+
+	return counts
+}
+
+func newJSONPluginCounts(pluginCounts map[parser.Plugin]int) []jsonPluginCount {
+	counts := make([]jsonPluginCount, 0, len(pluginCounts))
+	for _, plugin := range parser.SortedPlugins(pluginCounts) {
+		counts = append(counts, jsonPluginCount{
+			GUID:   plugin.GUID,
+			Name:   plugin.Name,
+			Format: plugin.Format(),
+			Count:  pluginCounts[plugin],
+		})
+	}
+
+	return counts
+}
+
+// roleList returns the roles provided or an empty list when there are none so that they're
+// encoded as an empty JSON array.
+func roleList(roles []parser.Role) []parser.Role {
+	if roles == nil {
+		return []parser.Role{}
+	}
+
+	return roles
+}
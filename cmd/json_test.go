@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	t.Parallel()
+
+	renderGolden(t, "json", FormatJSON, outputOptions{})
+}
+
+func TestJSONFormatterSchema(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	renderFormatter(t, newJSONFormatter(&b))
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(b.Bytes(), &document))
+
+	require.Equal(t, float64(JSONSchemaVersion), document["schema_version"])
+	require.Len(t, document["projects"], 2)
+	require.Len(t, document["failures"], 1)
+	require.Len(t, document["warnings"], 1)
+	require.ElementsMatch(
+		t,
+		[]string{"32_bit", "64_bit", "all", "by_role"},
+		mapKeys(document["summaries"].(map[string]any)),
+	)
+
+	project := document["projects"].([]any)[1].(map[string]any)
+	plugin := project["plugins"].([]any)[0].(map[string]any)
+	require.ElementsMatch(
+		t, []string{"guid", "name", "format", "instances", "roles"}, mapKeys(plugin),
+	)
+}
+
+func TestJSONFormatterNoProjects(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	require.NoError(t, newJSONFormatter(&b).Summary(scan.NewSummary()))
+
+	var document jsonDocument
+	require.NoError(t, json.Unmarshal(b.Bytes(), &document))
+	require.NotNil(t, document.Projects)
+	require.Empty(t, document.Projects)
+	require.Empty(t, document.Failures)
+	require.Empty(t, document.Warnings)
+}
+
+func mapKeys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
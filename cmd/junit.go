@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// The name of the test suite in JUnit XML reports.
+const junitSuiteName = "cubase-project-plugins check"
+
+// The root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// A test suite containing a test case for each project checked.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// A project checked, which fails when it violates the policy and errors when it can't be parsed.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+// The details of a failed or errored test case.
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes a JUnit XML report of the projects checked and the projects which could
+// not be parsed to the path provided.
+func writeJUnitReport(path string, checks []projectCheck, failures []scan.Failure) error {
+	suite := junitTestSuite{Name: junitSuiteName}
+
+	for _, check := range checks {
+		testCase := junitTestCase{Name: check.Path, ClassName: "projects"}
+
+		if len(check.Violations) > 0 {
+			var text strings.Builder
+			for _, violation := range check.Violations {
+				text.WriteString(describeViolation(violation))
+				text.WriteString("\n")
+			}
+
+			testCase.Failure = &junitProblem{
+				Message: fmt.Sprintf("%d policy violations", len(check.Violations)),
+				Type:    "PolicyViolation",
+				Text:    text.String(),
+			}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for _, failure := range failures {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      failure.Path,
+			ClassName: "projects",
+			Error: &junitProblem{
+				Message: failure.Err.Error(),
+				Type:    "ParseError",
+			},
+		})
+		suite.Errors++
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	data, err := xml.MarshalIndent(junitTestSuites{
+		Name:     junitSuiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0o644)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/policy"
+)
+
+// writeTestJUnitReport checks the test projects against a policy which denies Elephant and
+// Cubase versions after 12, writes a JUnit XML report and returns its contents.
+func writeTestJUnitReport(t *testing.T) []byte {
+	t.Helper()
+
+	checker, err := policy.NewChecker(config.Config{
+		Policy: config.Policy{
+			DeniedGUIDs: []string{"1C3A662167D347A99F7D797EA4911CDB"},
+			MaxVersion:  "12",
+		},
+	})
+	require.NoError(t, err)
+
+	report := scanTestProjects(t)
+
+	var checks []projectCheck
+	for _, result := range report.Results {
+		checks = append(
+			checks, projectCheck{Path: result.Path, Violations: checker.Check(result)},
+		)
+	}
+
+	path := filepath.Join(t.TempDir(), "policy.xml")
+	require.NoError(t, writeJUnitReport(path, checks, report.Failures))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	requireGolden(t, "junit", writeTestJUnitReport(t))
+}
+
+func TestWriteJUnitReportCounts(t *testing.T) {
+	t.Parallel()
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(writeTestJUnitReport(t), &suites))
+
+	require.Equal(t, 3, suites.Tests)
+	require.Equal(t, 2, suites.Failures)
+	require.Equal(t, 1, suites.Errors)
+	require.Len(t, suites.Suites, 1)
+
+	suite := suites.Suites[0]
+	require.Equal(t, junitSuiteName, suite.Name)
+	require.Equal(t, 3, suite.Tests)
+	require.Equal(t, 2, suite.Failures)
+	require.Equal(t, 1, suite.Errors)
+
+	require.Len(t, suite.TestCases, 3)
+	require.Equal(t, testProjectPaths[0], suite.TestCases[0].Name)
+	require.Equal(t, "1 policy violations", suite.TestCases[0].Failure.Message)
+	require.Equal(t, "2 policy violations", suite.TestCases[1].Failure.Message)
+	require.Nil(t, suite.TestCases[1].Error)
+	require.Equal(t, testProjectPaths[2], suite.TestCases[2].Name)
+	require.Equal(t, "ParseError", suite.TestCases[2].Error.Type)
+	require.Nil(t, suite.TestCases[2].Failure)
+}
+
+func TestWriteJUnitReportNoProjects(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "policy.xml")
+	require.NoError(t, writeJUnitReport(path, nil, nil))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	require.Zero(t, suites.Tests)
+	require.Empty(t, suites.Suites[0].TestCases)
+}
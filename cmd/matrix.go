@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// The value displayed in a matrix cell when a project uses a plugin.
+const matrixMark = "x"
+
+// Renders a project by plugin usage matrix once scanning has completed.  By default, each row is
+// a project and each column is a plugin, although this may be transposed.
+type matrixFormatter struct {
+	stderrProblems
+
+	w         io.Writer
+	markdown  bool
+	transpose bool
+	top       int
+	results   []scan.Result
+}
+
+func newMatrixFormatter(w io.Writer, markdown, transpose bool, top int) *matrixFormatter {
+	return &matrixFormatter{w: w, markdown: markdown, transpose: transpose, top: top}
+}
+
+func (f *matrixFormatter) Project(result scan.Result) error {
+	f.results = append(f.results, result)
+	return nil
+}
+
+func (f *matrixFormatter) Summary(summary scan.Summary) error {
+	plugins := matrixPlugins(summary.PluginCounts, f.top)
+	labels := pluginLabels(plugins)
+
+	used := make([]map[parser.Plugin]bool, len(f.results))
+	for i, result := range f.results {
+		used[i] = make(map[parser.Plugin]bool, len(result.Plugins))
+		for _, plugin := range result.Plugins {
+			used[i][plugin] = true
+		}
+	}
+
+	var rows [][]string
+
+	if f.transpose {
+		header := []string{"Plugin", "Format"}
+		for _, result := range f.results {
+			header = append(header, result.Path)
+		}
+		rows = append(rows, header)
+
+		for _, plugin := range plugins {
+			row := []string{labels[plugin], formatLabel(plugin.Format())}
+			for i := range f.results {
+				row = append(row, matrixCell(used[i][plugin]))
+			}
+			rows = append(rows, row)
+		}
+	} else {
+		// The format of each plugin is included in its column header (e.g. "Elephant (VST3)") so
+		// that every row after the header describes a project.
+		header := []string{"Project"}
+		for _, plugin := range plugins {
+			header = append(
+				header, fmt.Sprintf("%s (%s)", labels[plugin], formatLabel(plugin.Format())),
+			)
+		}
+		rows = append(rows, header)
+
+		for i, result := range f.results {
+			row := []string{result.Path}
+			for _, plugin := range plugins {
+				row = append(row, matrixCell(used[i][plugin]))
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if f.markdown {
+		return writeMarkdownTable(f.w, rows)
+	}
+
+	return csv.NewWriter(f.w).WriteAll(rows)
+}
+
+// matrixPlugins returns the plugins to include in a matrix ordered from most to least used.  When
+// top is greater than zero, only that many of the most used plugins are returned.
+func matrixPlugins(pluginCounts map[parser.Plugin]int, top int) []parser.Plugin {
+	plugins := parser.SortedPlugins(pluginCounts)
+	slices.SortStableFunc(plugins, func(a, b parser.Plugin) int {
+		return cmp.Compare(pluginCounts[b], pluginCounts[a])
+	})
+
+	if top > 0 && top < len(plugins) {
+		plugins = plugins[:top]
+	}
+
+	return plugins
+}
+
+// pluginLabels returns the label to display for each plugin provided.  Plugins are labelled by
+// name unless several plugins share the same name, in which case the GUID is also included.
+func pluginLabels(plugins []parser.Plugin) map[parser.Plugin]string {
+	nameCounts := make(map[string]int, len(plugins))
+	for _, plugin := range plugins {
+		nameCounts[plugin.Name]++
+	}
+
+	labels := make(map[parser.Plugin]string, len(plugins))
+	for _, plugin := range plugins {
+		if nameCounts[plugin.Name] > 1 {
+			labels[plugin] = fmt.Sprintf("%s (%s)", plugin.Name, plugin.GUID)
+		} else {
+			labels[plugin] = plugin.Name
+		}
+	}
+
+	return labels
+}
+
+func matrixCell(used bool) string {
+	if used {
+		return matrixMark
+	}
+
+	return ""
+}
+
+// writeMarkdownTable writes the rows provided as a Markdown table where the first row is the
+// table header.
+func writeMarkdownTable(w io.Writer, rows [][]string) error {
+	replacer := strings.NewReplacer("|", `\|`, "\n", " ")
+
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = replacer.Replace(cell)
+		}
+
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+
+		if i == 0 {
+			separators := make([]string, len(row))
+			for j := range separators {
+				separators[j] = "---"
+			}
+
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | ")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
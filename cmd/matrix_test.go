@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestMatrixFormatter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		format  string
+		options outputOptions
+	}{
+		{name: "matrix-csv", format: FormatMatrixCSV},
+		{name: "matrix-markdown", format: FormatMatrixMarkdown},
+		{
+			name:    "matrix-csv-transpose",
+			format:  FormatMatrixCSV,
+			options: outputOptions{MatrixTranspose: true},
+		},
+		{
+			name:    "matrix-markdown-top",
+			format:  FormatMatrixMarkdown,
+			options: outputOptions{MatrixTop: 3},
+		},
+		{
+			name:    "matrix-markdown-transpose-top",
+			format:  FormatMatrixMarkdown,
+			options: outputOptions{MatrixTranspose: true, MatrixTop: 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			renderGolden(t, tc.name, tc.format, tc.options)
+		})
+	}
+}
+
+func TestMatrixPlugins(t *testing.T) {
+	t.Parallel()
+
+	elephant := parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	eq := parser.Plugin{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"}
+	hive := parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+	counts := map[parser.Plugin]int{elephant: 1, eq: 3, hive: 1}
+
+	// Plugins are ordered from most to least used and then by name.
+	require.Equal(t, []parser.Plugin{eq, elephant, hive}, matrixPlugins(counts, 0))
+	require.Equal(t, []parser.Plugin{eq, elephant}, matrixPlugins(counts, 2))
+	require.Equal(t, []parser.Plugin{eq, elephant, hive}, matrixPlugins(counts, 10))
+}
+
+func TestPluginLabels(t *testing.T) {
+	t.Parallel()
+
+	kontakt5 := parser.Plugin{GUID: "5653544B6E74356B6F6E74616B742035", Name: "Kontakt"}
+	kontakt7 := parser.Plugin{GUID: "5653544B6E74376B6F6E74616B742037", Name: "Kontakt"}
+	hive := parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+
+	require.Equal(
+		t,
+		map[parser.Plugin]string{
+			kontakt5: "Kontakt (5653544B6E74356B6F6E74616B742035)",
+			kontakt7: "Kontakt (5653544B6E74376B6F6E74616B742037)",
+			hive:     "Hive",
+		},
+		pluginLabels([]parser.Plugin{kontakt5, kontakt7, hive}),
+	)
+}
+
+func TestWriteMarkdownTable(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	require.NoError(t, writeMarkdownTable(&b, [][]string{
+		{"Project", "A|B"},
+		{"Line\nBreak", "x"},
+	}))
+
+	require.Equal(
+		t,
+		"| Project | A\\|B |\n| --- | --- |\n| Line Break | x |\n",
+		b.String(),
+	)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// The record types emitted when using the NDJSON output format.
+const (
+	ndjsonTypeProject = "project"
+	ndjsonTypeError   = "error"
+	ndjsonTypeWarning = "warning"
+	ndjsonTypeSummary = "summary"
+)
+
+// A project record emitted as soon as a project has been parsed.
+type ndjsonProject struct {
+	Type          string `json:"type"`           // always "project"
+	SchemaVersion int    `json:"schema_version"` // version of the record structure
+	jsonProject
+}
+
+// An error record emitted when a project could not be parsed.
+type ndjsonError struct {
+	Type          string `json:"type"`           // always "error"
+	SchemaVersion int    `json:"schema_version"` // version of the record structure
+	Path          string `json:"path"`           // path to the project file
+	Error         string `json:"error"`          // description of the error which occurred
+}
+
+// A warning record emitted when a path could not be walked or read.
+type ndjsonWarning struct {
+	Type          string `json:"type"`           // always "warning"
+	SchemaVersion int    `json:"schema_version"` // version of the record structure
+	jsonWarning
+}
+
+// The summary record emitted once all projects have been scanned.
+type ndjsonSummary struct {
+	Type          string        `json:"type"`           // always "summary"
+	SchemaVersion int           `json:"schema_version"` // version of the record structure
+	Summaries     jsonSummaries `json:"summaries"`      // plugin usage by project architecture
+}
+
+// Renders scan results as newline-delimited JSON, writing one record per project as soon as it
+// has been scanned followed by a final summary record.  Projects which fail to parse are reported
+// as error records and paths which could not be walked or read are reported as warning records.
+type ndjsonFormatter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONFormatter(w io.Writer) *ndjsonFormatter {
+	return &ndjsonFormatter{encoder: json.NewEncoder(w)}
+}
+
+func (f *ndjsonFormatter) Project(result scan.Result) error {
+	return f.encoder.Encode(ndjsonProject{
+		Type:          ndjsonTypeProject,
+		SchemaVersion: JSONSchemaVersion,
+		jsonProject:   newJSONProject(result),
+	})
+}
+
+func (f *ndjsonFormatter) ProjectError(failure scan.Failure) error {
+	return f.encoder.Encode(ndjsonError{
+		Type:          ndjsonTypeError,
+		SchemaVersion: JSONSchemaVersion,
+		Path:          failure.Path,
+		Error:         failure.Err.Error(),
+	})
+}
+
+func (f *ndjsonFormatter) Warning(warning scan.Warning) error {
+	return f.encoder.Encode(ndjsonWarning{
+		Type:          ndjsonTypeWarning,
+		SchemaVersion: JSONSchemaVersion,
+		jsonWarning:   newJSONWarning(warning),
+	})
+}
+
+func (f *ndjsonFormatter) Summary(summary scan.Summary) error {
+	return f.encoder.Encode(ndjsonSummary{
+		Type:          ndjsonTypeSummary,
+		SchemaVersion: JSONSchemaVersion,
+		Summaries:     newJSONSummaries(summary),
+	})
+}
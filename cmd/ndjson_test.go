@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONFormatter(t *testing.T) {
+	t.Parallel()
+
+	renderGolden(t, "ndjson", FormatNDJSON, outputOptions{})
+}
+
+func TestNDJSONFormatterRecords(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	renderFormatter(t, newNDJSONFormatter(&b))
+
+	var types []string
+	scanner := bufio.NewScanner(&b)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		require.Equal(t, float64(JSONSchemaVersion), record["schema_version"])
+
+		types = append(types, record["type"].(string))
+	}
+	require.NoError(t, scanner.Err())
+
+	// Records are written in the order paths are walked, followed by the summary.
+	require.Equal(
+		t,
+		[]string{
+			ndjsonTypeProject,
+			ndjsonTypeProject,
+			ndjsonTypeError,
+			ndjsonTypeWarning,
+			ndjsonTypeSummary,
+		},
+		types,
+	)
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var ErrUnknownFormat = errors.New("the output format requested is not supported")
+
+// The output formats supported by the tool.
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatTSV    = "tsv"
+
+	FormatMatrixCSV      = "matrix-csv"
+	FormatMatrixMarkdown = "matrix-markdown"
+	FormatHTML           = "html"
+)
+
+// Options which customise the output of particular formats.
+type outputOptions struct {
+	MatrixTranspose bool // whether matrix rows should be plugins and columns should be projects
+	MatrixTop       int  // the number of most used plugins to include in a matrix (0 for all)
+	SummaryDetails  bool // whether the projects using each plugin should be listed in summaries
+	SummaryByRole   bool // whether summaries should be grouped by plugin role instead of bitness
+}
+
+// Renders scan results in a particular output format.  Project is called for each project as
+// soon as it has been scanned, ProjectError is called for each project which could not be parsed,
+// Warning is called for each path which could not be walked or read and Summary is called once
+// after all projects have been scanned.
+type formatter interface {
+	Project(result scan.Result) error
+	ProjectError(failure scan.Failure) error
+	Warning(warning scan.Warning) error
+	Summary(summary scan.Summary) error
+}
+
+// Reports projects which could not be parsed and paths which could not be walked or read on
+// stderr.  This is embedded in formatters which have no way to include these in their output.
+type stderrProblems struct{}
+
+func (stderrProblems) ProjectError(failure scan.Failure) error {
+	_, err := fmt.Fprintf(os.Stderr, "Error: %s: %v\n", failure.Path, failure.Err)
+	return err
+}
+
+func (stderrProblems) Warning(warning scan.Warning) error {
+	_, err := fmt.Fprintf(
+		os.Stderr, "Warning: %s: unable to %s: %v\n", warning.Path, warning.Op, warning.Err,
+	)
+	return err
+}
+
+// newFormatter returns a formatter for the requested format which writes to the writer provided.
+func newFormatter(format string, w io.Writer, options outputOptions) (formatter, error) {
+	switch format {
+	case FormatText:
+		return newTextFormatter(w, options), nil
+	case FormatJSON:
+		return newJSONFormatter(w), nil
+	case FormatNDJSON:
+		return newNDJSONFormatter(w), nil
+	case FormatCSV:
+		return newCSVFormatter(w, ','), nil
+	case FormatTSV:
+		return newCSVFormatter(w, '\t'), nil
+	case FormatMatrixCSV, FormatMatrixMarkdown:
+		return newMatrixFormatter(
+			w, format == FormatMatrixMarkdown, options.MatrixTranspose, options.MatrixTop,
+		), nil
+	case FormatHTML:
+		return newHTMLFormatter(w), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// The headings of the summaries of plugins used in each role.
+var roleSummaryHeadings = map[parser.Role]string{
+	parser.RoleInstrument:   "Summary: Plugins Used As Instruments",
+	parser.RoleInsert:       "Summary: Plugins Used As Inserts",
+	parser.RoleSend:         "Summary: Plugins Used In Sends",
+	parser.RoleChannelStrip: "Summary: Plugins Used In Channel Strips",
+	parser.RoleMaster:       "Summary: Plugins Used On The Master Bus",
+	parser.RoleDither:       "Summary: Plugins Used For Dithering",
+}
+
+// Renders scan results as colored human-readable text.
+type textFormatter struct {
+	w              io.Writer
+	heading        *color.Color
+	subHeading     *color.Color
+	summaryDetails bool
+	summaryByRole  bool
+	failures       []scan.Failure
+	warnings       []scan.Warning
+}
+
+func newTextFormatter(w io.Writer, options outputOptions) *textFormatter {
+	return &textFormatter{
+		w:              w,
+		heading:        color.New(color.BgRed, color.FgHiWhite),
+		subHeading:     color.New(color.FgHiBlue),
+		summaryDetails: options.SummaryDetails,
+		summaryByRole:  options.SummaryByRole,
+	}
+}
+
+func (f *textFormatter) Project(result scan.Result) error {
+	fmt.Fprintln(f.w)
+	f.heading.Fprintf(f.w, "Path: %s", result.Path)
+	fmt.Fprintln(f.w)
+
+	fmt.Fprintln(f.w)
+	f.subHeading.Fprintf(
+		f.w,
+		"%s %s (%s)",
+		result.Project.Metadata.Application,
+		result.Project.Metadata.Version,
+		result.Project.Metadata.Architecture,
+	)
+	fmt.Fprintln(f.w)
+
+	if len(result.Plugins) == 0 {
+		return nil
+	}
+
+	trackTitles := result.Project.TrackTitles()
+
+	fmt.Fprintln(f.w)
+	for _, plugin := range result.Plugins {
+		if titles := trackTitles[plugin]; len(titles) > 0 {
+			fmt.Fprintf(
+				f.w,
+				"    > %s : %s : %s (track titles: %s)\n",
+				plugin.GUID,
+				formatLabel(plugin.Format()),
+				plugin.Name,
+				strings.Join(titles, ", "),
+			)
+		} else {
+			fmt.Fprintf(
+				f.w, "    > %s : %s : %s\n", plugin.GUID, formatLabel(plugin.Format()), plugin.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (f *textFormatter) ProjectError(failure scan.Failure) error {
+	f.failures = append(f.failures, failure)
+	return nil
+}
+
+func (f *textFormatter) Warning(warning scan.Warning) error {
+	f.warnings = append(f.warnings, warning)
+	return nil
+}
+
+func (f *textFormatter) Summary(summary scan.Summary) error {
+	if f.summaryByRole {
+		for _, role := range parser.Roles {
+			f.printSummary(
+				summary.RoleCounts[role], summary.RoleProjects[role], roleSummaryHeadings[role],
+			)
+		}
+	} else {
+		f.printSummary(
+			summary.PluginCounts32,
+			summary.PluginProjects32,
+			"Summary: Plugins Used In 32-bit Projects",
+		)
+		f.printSummary(
+			summary.PluginCounts64,
+			summary.PluginProjects64,
+			"Summary: Plugins Used In 64-bit Projects",
+		)
+		f.printSummary(
+			summary.PluginCounts, summary.PluginProjects, "Summary: Plugins Used In All Projects",
+		)
+	}
+
+	printFailures(f.w, f.heading, f.failures)
+	printWarnings(f.w, f.heading, f.warnings)
+
+	return nil
+}
+
+func (f *textFormatter) printSummary(
+	pluginCounts map[parser.Plugin]int,
+	pluginProjects map[parser.Plugin][]string,
+	heading string,
+) {
+	if len(pluginCounts) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.w)
+	f.heading.Fprint(f.w, heading)
+	fmt.Fprintln(f.w)
+	fmt.Fprintln(f.w)
+
+	for _, plugin := range parser.SortedPlugins(pluginCounts) {
+		count := pluginCounts[plugin]
+		fmt.Fprintf(
+			f.w,
+			"    > %s : %s : %s (%d)\n",
+			plugin.GUID,
+			formatLabel(plugin.Format()),
+			plugin.Name,
+			count,
+		)
+
+		if f.summaryDetails {
+			paths := slices.Clone(pluginProjects[plugin])
+			slices.Sort(paths)
+
+			for _, path := range paths {
+				fmt.Fprintf(f.w, "        - %s\n", path)
+			}
+		}
+	}
+}
+
+// printFailures prints a section listing each project which could not be parsed.
+func printFailures(w io.Writer, heading *color.Color, failures []scan.Failure) {
+	if len(failures) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	heading.Fprint(w, "Failed Projects")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	for _, failure := range failures {
+		fmt.Fprintf(w, "    > %s : %v\n", failure.Path, failure.Err)
+	}
+}
+
+// printWarnings prints a section listing each path which could not be walked or read.
+func printWarnings(w io.Writer, heading *color.Color, warnings []scan.Warning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	heading.Fprint(w, "Warnings")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "    > %s : unable to %s : %v\n", warning.Path, warning.Op, warning.Err)
+	}
+}
+
+// formatLabel returns the plugin format provided for display (e.g. "VST2").
+func formatLabel(format parser.Format) string {
+	return strings.ToUpper(string(format))
+}
@@ -0,0 +1,36 @@
+"use strict";
+
+// Makes each table with the sortable class sortable by clicking on its column headings.
+document.querySelectorAll("table.sortable").forEach((table) => {
+  table.querySelectorAll("th").forEach((th, column) => {
+    th.addEventListener("click", () => {
+      const order = th.dataset.order === "asc" ? "desc" : "asc";
+      table.querySelectorAll("th").forEach((other) => delete other.dataset.order);
+      th.dataset.order = order;
+
+      const tbody = table.tBodies[0];
+      const rows = Array.from(tbody.rows);
+      rows.sort((a, b) => {
+        const x = a.cells[column].dataset.value ?? a.cells[column].textContent;
+        const y = b.cells[column].dataset.value ?? b.cells[column].textContent;
+        const result =
+          th.dataset.type === "number"
+            ? Number(x) - Number(y)
+            : x.localeCompare(y, undefined, { sensitivity: "base" });
+        return order === "asc" ? result : -result;
+      });
+      rows.forEach((row) => tbody.appendChild(row));
+    });
+  });
+});
+
+// Hides table rows which don't contain the text entered in the related filter input.
+document.querySelectorAll("input.filter").forEach((input) => {
+  const table = document.getElementById(input.dataset.table);
+  input.addEventListener("input", () => {
+    const query = input.value.toLowerCase();
+    Array.from(table.tBodies[0].rows).forEach((row) => {
+      row.hidden = !row.textContent.toLowerCase().includes(query);
+    });
+  });
+});
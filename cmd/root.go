@@ -1,31 +1,46 @@
 package cmd
 
 import (
-	"cmp"
+	"context"
 	"errors"
 	"fmt"
-	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
-	"slices"
-	"strings"
 
 	"github.com/BurntSushi/toml"
-	"github.com/bmatcuk/doublestar/v4"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/fgimian/cubase-project-plugins/cache"
 	"github.com/fgimian/cubase-project-plugins/config"
 	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
 )
 
 var (
 	ErrOpenConfigFile  = errors.New("unable to open the config file requested")
 	ErrParseConfigFile = errors.New("unable to parse the config file requested")
+	ErrProjectsFailed  = errors.New("one or more projects could not be parsed")
+	ErrScanWarnings    = errors.New("one or more paths could not be walked or read")
 )
 
-var configPath string
+var (
+	configPath     string
+	failFast       bool
+	strict         bool
+	jobs           int
+	format         string
+	summaryCSVPath string
+	templatePath   string
+	minVersion     string
+	maxVersion     string
+	order          string
+	role           string
+	pluginFormat   string
+	outputOpts     outputOptions
+)
 
 var rootCmd = &cobra.Command{
 	Use: "cubase-project-plugins [flags] [project path]...",
@@ -35,141 +50,85 @@
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
-		heading := color.New(color.BgRed, color.FgHiWhite)
-		subHeading := color.New(color.FgHiBlue)
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
 
-		config := config.Config{
-			Projects: config.Projects{
-				Report32Bit: true,
-				Report64Bit: true,
-			},
+		if minVersion != "" {
+			config.Projects.MinVersion = minVersion
 		}
 
-		if configPath == "" {
-			defaultConfigPath := getDefaultConfigPath()
-			if defaultConfigPath != "" {
-				if _, err := os.Stat(defaultConfigPath); err == nil {
-					configPath = defaultConfigPath
-				}
-			}
+		if maxVersion != "" {
+			config.Projects.MaxVersion = maxVersion
 		}
 
-		if configPath != "" {
-			f, err := os.Open(configPath)
-			if err != nil {
-				return ErrOpenConfigFile
-			}
-			defer f.Close()
+		pluginOrder, err := scan.ParseOrder(order)
+		if err != nil {
+			return err
+		}
 
-			_, err = toml.NewDecoder(f).Decode(&config)
+		var pluginRole parser.Role
+		if role != "" {
+			pluginRole, err = parser.ParseRole(role)
 			if err != nil {
-				return ErrParseConfigFile
+				return err
 			}
 		}
 
-		pluginCounts := make(map[parser.Plugin]int)
-		pluginCounts32 := make(map[parser.Plugin]int)
-		pluginCounts64 := make(map[parser.Plugin]int)
-
-		for _, projectPath := range args {
-			err := filepath.Walk(
-				projectPath,
-				func(path string, _ fs.FileInfo, err error) error {
-					if err != nil || filepath.Ext(path) != ".cpr" {
-						return nil
-					}
-
-					for _, pathIgnorePattern := range config.PathIgnorePatterns {
-						match, err := doublestar.Match(
-							filepath.ToSlash(pathIgnorePattern),
-							filepath.ToSlash(path),
-						)
-						if err == nil && match {
-							return nil
-						}
-					}
-
-					projectBytes, err := os.ReadFile(path)
-					if err != nil {
-						return nil
-					}
-
-					reader := parser.NewReader(projectBytes)
-					project, err := reader.GetProjectDetails()
-					if err != nil {
-						return err
-					}
-
-					is64Bit := project.Metadata.Architecture == "WIN64" ||
-						project.Metadata.Architecture == "MAC64 LE"
-
-					if is64Bit && !config.Projects.Report64Bit ||
-						!is64Bit && !config.Projects.Report32Bit {
-						return nil
-					}
-
-					fmt.Println()
-					heading.Printf("Path: %s", path)
-					fmt.Println()
-
-					fmt.Println()
-					subHeading.Printf(
-						"%s %s (%s)",
-						project.Metadata.Application,
-						project.Metadata.Version,
-						project.Metadata.Architecture,
-					)
-					fmt.Println()
-
-					var displayPlugins []parser.Plugin
-
-					for _, plugin := range project.Plugins {
-						if slices.Contains(config.Plugins.GUIDIgnores, plugin.GUID) ||
-							slices.Contains(config.Plugins.NameIgnores, plugin.Name) {
-							continue
-						}
-
-						displayPlugins = append(displayPlugins, plugin)
-					}
-
-					if len(displayPlugins) == 0 {
-						return nil
-					}
-
-					slices.SortFunc(displayPlugins, func(a, b parser.Plugin) int {
-						return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
-					})
-
-					fmt.Println()
-					for _, plugin := range displayPlugins {
-						pluginCounts[plugin]++
-						if is64Bit {
-							pluginCounts64[plugin]++
-						} else {
-							pluginCounts32[plugin]++
-						}
-
-						fmt.Printf("    > %s : %s\n", plugin.GUID, plugin.Name)
-					}
-
-					return nil
-				},
-			)
+		var pluginFormatFilter parser.Format
+		if pluginFormat != "" {
+			pluginFormatFilter, err = parser.ParseFormat(pluginFormat)
 			if err != nil {
 				return err
 			}
 		}
 
-		printSummary(pluginCounts32, "32-bit", heading)
-		printSummary(pluginCounts64, "64-bit", heading)
-		printSummary(pluginCounts, "All", heading)
+		var out formatter
+		if templatePath != "" {
+			out, err = newTemplateFormatter(os.Stdout, templatePath)
+		} else {
+			out, err = newFormatter(format, os.Stdout, outputOpts)
+		}
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		scanner := newScanner(config, projectCache)
+		scanner.Order = pluginOrder
+		scanner.Role = pluginRole
+		scanner.PluginFormat = pluginFormatFilter
+		scanner.OnResult = out.Project
+		scanner.OnFailure = out.ProjectError
+		scanner.OnWarning = out.Warning
+
+		report, err := scanner.Scan(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+
+		if err := out.Summary(report.Summary); err != nil {
+			return err
+		}
+
+		if summaryCSVPath != "" {
+			if err := writeSummaryCSV(summaryCSVPath, report.Summary); err != nil {
+				return err
+			}
+		}
 
-		return nil
+		return scanError(report.Failures, report.Warnings)
 	},
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return rootCmd.ExecuteContext(ctx)
 }
 
 func init() {
@@ -178,40 +137,154 @@ func init() {
 	}
 
 	_ = rootCmd.MarkFlagRequired("project-path")
-	rootCmd.Flags().
+	rootCmd.PersistentFlags().
 		StringVarP(&configPath, "config", "c", "", "config file `path`")
+	addScanFlags(rootCmd)
+	addStrictFlag(rootCmd)
+	rootCmd.Flags().
+		StringVarP(
+			&format,
+			"format",
+			"f",
+			FormatText,
+			"output `format` (text, json, ndjson, csv, tsv, matrix-csv, matrix-markdown or html)",
+		)
+	rootCmd.Flags().
+		StringVar(
+			&summaryCSVPath, "summary-csv", "", "write the plugin usage summary as CSV to `path`",
+		)
+	rootCmd.Flags().
+		BoolVar(
+			&outputOpts.SummaryDetails,
+			"summary-details",
+			false,
+			"list the projects using each plugin in the summaries",
+		)
+	rootCmd.Flags().
+		StringVar(&templatePath, "template", "", "render output using the Go template at `path`")
+	rootCmd.Flags().
+		BoolVar(&outputOpts.MatrixTranspose, "transpose", false, "use plugins as matrix rows")
+	rootCmd.Flags().
+		IntVar(&outputOpts.MatrixTop, "top", 0, "limit matrix plugins to the `N` most used")
+	rootCmd.Flags().
+		StringVar(&minVersion, "min-version", "", "only report projects from Cubase `version`")
+	rootCmd.Flags().
+		StringVar(&maxVersion, "max-version", "", "only report projects up to Cubase `version`")
+	rootCmd.Flags().
+		StringVar(
+			&order,
+			"order",
+			string(scan.OrderName),
+			"`order` of the plugins listed for each project (appearance, name or guid)",
+		)
+	rootCmd.Flags().
+		StringVar(
+			&role,
+			"role",
+			"",
+			"only report plugins used in this `role` (instrument, insert, send, channel_strip, "+
+				"master or dither)",
+		)
+	rootCmd.Flags().
+		BoolVar(
+			&outputOpts.SummaryByRole,
+			"summary-by-role",
+			false,
+			"group the summaries by the role of each plugin",
+		)
+	rootCmd.Flags().
+		StringVar(
+			&pluginFormat,
+			"plugin-format",
+			"",
+			"only report plugins of this `format` (vst2 or vst3)",
+		)
 }
 
-func getDefaultConfigPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
+// addScanFlags adds the flags which are honoured by newScanner to a command which scans projects.
+func addScanFlags(cmd *cobra.Command) {
+	cmd.Flags().
+		IntVarP(
+			&jobs, "jobs", "j", runtime.GOMAXPROCS(0), "number of projects to parse concurrently",
+		)
+	cmd.Flags().
+		BoolVar(&noCache, "no-cache", false, "don't use or update the cache of parsed projects")
+	cmd.Flags().
+		BoolVar(&failFast, "fail-fast", false, "stop scanning when a project can't be parsed")
+}
 
-	return filepath.Join(home, ".config", "cubase-project-plugins.toml")
+// addStrictFlag adds the flag which is honoured by scanError to a command which scans projects.
+func addStrictFlag(cmd *cobra.Command) {
+	cmd.Flags().
+		BoolVar(&strict, "strict", false, "fail when a path can't be walked or read")
 }
 
-func printSummary(pluginCounts map[parser.Plugin]int, description string, heading *color.Color) {
-	if len(pluginCounts) == 0 {
-		return
+// loadConfig loads the config file requested or the default config file if it exists.
+func loadConfig() (config.Config, error) {
+	cfg := config.Config{
+		Projects: config.Projects{
+			Report32Bit: true,
+			Report64Bit: true,
+		},
+	}
+
+	if configPath == "" {
+		defaultConfigPath := getDefaultConfigPath()
+		if defaultConfigPath != "" {
+			if _, err := os.Stat(defaultConfigPath); err == nil {
+				configPath = defaultConfigPath
+			}
+		}
+	}
+
+	if configPath != "" {
+		f, err := os.Open(configPath)
+		if err != nil {
+			return cfg, ErrOpenConfigFile
+		}
+		defer f.Close()
+
+		_, err = toml.NewDecoder(f).Decode(&cfg)
+		if err != nil {
+			return cfg, ErrParseConfigFile
+		}
 	}
 
-	fmt.Println()
-	heading.Printf("Summary: Plugins Used In %s Projects", description)
-	fmt.Println()
-	fmt.Println()
+	return cfg, nil
+}
+
+// newScanner returns a scanner using the config and cache provided which honours the scan related
+// flags.
+func newScanner(config config.Config, projectCache *cache.Cache) *scan.Scanner {
+	scanner := scan.NewScanner(config)
+	scanner.Jobs = jobs
+	scanner.Cache = projectCache
+	scanner.FailFast = failFast
+
+	return scanner
+}
+
+// scanError returns the error which should be reported after a scan based on the projects which
+// could not be parsed and the paths which could not be walked or read.
+func scanError(failures []scan.Failure, warnings []scan.Warning) error {
+	var errs []error
+
+	if len(failures) > 0 {
+		errs = append(errs, fmt.Errorf("%w (%d failed)", ErrProjectsFailed, len(failures)))
+	}
 
-	plugins := make([]parser.Plugin, 0, len(pluginCounts))
-	for plugin := range pluginCounts {
-		plugins = append(plugins, plugin)
+	if strict && len(warnings) > 0 {
+		errs = append(errs, fmt.Errorf("%w (%d warnings)", ErrScanWarnings, len(warnings)))
 	}
 
-	slices.SortFunc(plugins, func(a, b parser.Plugin) int {
-		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
-	})
+	return errors.Join(errs...)
+}
 
-	for _, plugin := range plugins {
-		count := pluginCounts[plugin]
-		fmt.Printf("    > %s : %s (%d)\n", plugin.GUID, plugin.Name, count)
+func getDefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
 	}
+
+	return filepath.Join(home, ".config", "cubase-project-plugins.toml")
 }
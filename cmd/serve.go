@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// The time allowed for in-flight requests to complete when the server is stopped.
+const serveShutdownTimeout = 5 * time.Second
+
+// The header which must be sent with requests that change the state of the server.  Browsers
+// don't allow other sites to send custom headers without a CORS preflight request, which the
+// server never approves, so this prevents cross-site request forgery.
+const serveRequestedWithHeader = "X-Requested-With"
+
+var (
+	serveAddress         string
+	serveRefreshInterval time.Duration
+)
+
+//go:embed serve/index.html
+var serveIndexHTML []byte
+
+var serveCmd = &cobra.Command{
+	Use:   "serve [flags] [project path]...",
+	Short: "Serves a JSON API and browsable UI describing the plugins used in your projects.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+
+		inventory := newProjectInventory(config, args)
+		if err := inventory.refresh(ctx); err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("tcp", serveAddress)
+		if err != nil {
+			return err
+		}
+
+		server := &http.Server{
+			Handler:           inventory.handler(serveAddress),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			<-ctx.Done()
+
+			shutdownCtx, cancel := context.WithTimeout(
+				context.Background(), serveShutdownTimeout,
+			)
+			defer cancel()
+
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		if serveRefreshInterval > 0 {
+			go inventory.refreshEvery(ctx, serveRefreshInterval)
+		}
+
+		fmt.Printf(
+			"Serving %d projects on http://%s\n", inventory.projectCount(), listener.Addr(),
+		)
+
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().
+		StringVarP(&serveAddress, "address", "a", "127.0.0.1:8080", "`address` to listen on")
+	serveCmd.Flags().
+		DurationVar(
+			&serveRefreshInterval,
+			"refresh-interval",
+			0,
+			"how often to scan projects again (0 to only scan on demand)",
+		)
+
+	addScanFlags(serveCmd)
+	rootCmd.AddCommand(serveCmd)
+}
+
+// The status of the inventory returned by the summary and refresh endpoints.
+type serveStatus struct {
+	ScannedAt time.Time     `json:"scanned_at"` // time the most recent scan completed
+	Projects  int           `json:"projects"`   // number of projects scanned
+	Summaries jsonSummaries `json:"summaries"`  // plugin usage by project architecture
+	Failures  []jsonFailure `json:"failures"`   // projects which could not be parsed
+	Warnings  []jsonWarning `json:"warnings"`   // paths which could not be walked or read
+}
+
+// Holds the results of the most recent scan of the project paths in memory.
+type projectInventory struct {
+	config    config.Config
+	paths     []string
+	refreshMu sync.Mutex // ensures only one scan runs at a time
+
+	mu        sync.RWMutex
+	report    *scan.Report
+	scannedAt time.Time
+}
+
+func newProjectInventory(config config.Config, paths []string) *projectInventory {
+	return &projectInventory{config: config, paths: paths}
+}
+
+// refresh scans all project paths and replaces the results held in the inventory.
+func (i *projectInventory) refresh(ctx context.Context) error {
+	i.refreshMu.Lock()
+	defer i.refreshMu.Unlock()
+
+	projectCache := openCache()
+	defer saveCache(projectCache)
+
+	// A server should keep running when projects can't be parsed, so failures are always
+	// collected rather than stopping the scan.
+	scanner := newScanner(i.config, projectCache)
+	scanner.FailFast = false
+
+	report, err := scanner.Scan(ctx, i.paths)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.report = report
+	i.scannedAt = time.Now()
+
+	return nil
+}
+
+// refreshEvery refreshes the inventory at the interval provided until the context is cancelled.
+func (i *projectInventory) refreshEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := i.refresh(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				fmt.Fprintf(os.Stderr, "Warning: unable to refresh projects: %v\n", err)
+			}
+		}
+	}
+}
+
+func (i *projectInventory) projectCount() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.report.Results)
+}
+
+// handler returns the HTTP handler serving the API and UI on the listen address provided.
+func (i *projectInventory) handler(address string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(serveIndexHTML)
+	})
+	mux.HandleFunc("GET /projects", i.handleProjects)
+	mux.HandleFunc("GET /projects/{path...}", i.handleProject)
+	mux.HandleFunc("GET /plugins", i.handlePlugins)
+	mux.HandleFunc("GET /plugins/{guid}/projects", i.handlePluginProjects)
+	mux.HandleFunc("GET /summary", i.handleSummary)
+	mux.HandleFunc("POST /refresh", i.handleRefresh)
+
+	return serveHostCheck(address, mux)
+}
+
+// serveHostCheck rejects requests whose Host header doesn't refer to the listen address provided
+// or the local machine, which prevents other sites from reading the API using DNS rebinding.
+// Any IP address is permitted when listening on all interfaces since DNS isn't involved.
+func serveHostCheck(address string, next http.Handler) http.Handler {
+	listenHost, _, err := net.SplitHostPort(address)
+	if err != nil {
+		listenHost = address
+	}
+
+	listenIP := net.ParseIP(listenHost)
+	allowAnyIP := listenHost == "" || (listenIP != nil && listenIP.IsUnspecified())
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+
+		host = strings.Trim(host, "[]")
+		ip := net.ParseIP(host)
+
+		if strings.EqualFold(host, listenHost) ||
+			strings.EqualFold(host, "localhost") ||
+			(ip != nil && (ip.IsLoopback() || allowAnyIP || ip.Equal(listenIP))) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		writeJSONError(w, http.StatusForbidden, "host not permitted")
+	})
+}
+
+func (i *projectInventory) handleProjects(w http.ResponseWriter, _ *http.Request) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	projects := make([]jsonProject, 0, len(i.report.Results))
+	for _, result := range i.report.Results {
+		projects = append(projects, newJSONProject(result))
+	}
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (i *projectInventory) handleProject(w http.ResponseWriter, r *http.Request) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// The leading slash of absolute paths is removed when they are joined to the URL, so paths
+	// are compared without it.
+	path := strings.TrimPrefix(r.PathValue("path"), "/")
+	for _, result := range i.report.Results {
+		if strings.TrimPrefix(filepath.ToSlash(result.Path), "/") == path {
+			writeJSON(w, http.StatusOK, newJSONProject(result))
+			return
+		}
+	}
+
+	writeJSONError(w, http.StatusNotFound, "project not found")
+}
+
+func (i *projectInventory) handlePlugins(w http.ResponseWriter, _ *http.Request) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, i.report.Summary.SortedPluginCounts())
+}
+
+func (i *projectInventory) handlePluginProjects(w http.ResponseWriter, r *http.Request) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	guid := r.PathValue("guid")
+
+	projects := []jsonProject{}
+	for _, result := range i.report.Results {
+		for _, plugin := range result.Plugins {
+			if strings.EqualFold(plugin.GUID, guid) {
+				projects = append(projects, newJSONProject(result))
+				break
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+func (i *projectInventory) handleSummary(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, i.status())
+}
+
+func (i *projectInventory) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(serveRequestedWithHeader) == "" {
+		writeJSONError(
+			w, http.StatusForbidden, "the "+serveRequestedWithHeader+" header is required",
+		)
+		return
+	}
+
+	if err := i.refresh(r.Context()); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, i.status())
+}
+
+func (i *projectInventory) status() serveStatus {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	status := serveStatus{
+		ScannedAt: i.scannedAt,
+		Projects:  len(i.report.Results),
+		Summaries: newJSONSummaries(i.report.Summary),
+		Failures:  make([]jsonFailure, 0, len(i.report.Failures)),
+		Warnings:  make([]jsonWarning, 0, len(i.report.Warnings)),
+	}
+
+	for _, failure := range i.report.Failures {
+		status.Failures = append(status.Failures, newJSONFailure(failure))
+	}
+
+	for _, warning := range i.report.Warnings {
+		status.Warnings = append(status.Warnings, newJSONWarning(warning))
+	}
+
+	return status
+}
+
+// writeJSON writes the value provided as the JSON response body with the status code provided.
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeJSONError writes a JSON response describing an error with the status code provided.
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"error": message})
+}
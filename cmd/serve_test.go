@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// newTestInventory returns an inventory holding the results of scanning the test projects.
+func newTestInventory(t *testing.T) *projectInventory {
+	t.Helper()
+
+	inventory := newProjectInventory(testConfig(), testProjectPaths)
+	inventory.report = scanTestProjects(t)
+
+	return inventory
+}
+
+// serveRequest sends a request to the handler provided and returns the response recorded.
+func serveRequest(
+	t *testing.T, handler http.Handler, method, target string, header http.Header,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(method, target, nil)
+	r.Host = "127.0.0.1:8080"
+	for name, values := range header {
+		r.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+// decodeResponse decodes the JSON body of the response provided.
+func decodeResponse[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var value T
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &value))
+
+	return value
+}
+
+func TestServeIndex(t *testing.T) {
+	t.Parallel()
+
+	w := serveRequest(t, newTestInventory(t).handler("127.0.0.1:8080"), "GET", "/", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, serveIndexHTML, w.Body.Bytes())
+}
+
+func TestServeProjects(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestInventory(t).handler("127.0.0.1:8080")
+
+	w := serveRequest(t, handler, "GET", "/projects", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	projects := decodeResponse[[]jsonProject](t, w)
+	require.Len(t, projects, 2)
+	require.Equal(t, testProjectPaths[0], projects[0].Path)
+	require.Equal(t, "5.5.3", projects[0].Metadata.Version)
+
+}
+
+func TestServeProject(t *testing.T) {
+	t.Parallel()
+
+	// Projects are requested by the absolute path they were scanned from without its leading
+	// slash.
+	projectPath, err := filepath.Abs(testProjectPaths[1])
+	require.NoError(t, err)
+
+	report, err := scan.NewScanner(testConfig()).
+		Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	inventory := newProjectInventory(testConfig(), []string{projectPath})
+	inventory.report = report
+	handler := inventory.handler("127.0.0.1:8080")
+
+	projectURL := url.URL{
+		Path: "/projects/" + strings.TrimPrefix(filepath.ToSlash(projectPath), "/"),
+	}
+	w := serveRequest(t, handler, "GET", projectURL.EscapedPath(), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, projectPath, decodeResponse[jsonProject](t, w).Path)
+
+	w = serveRequest(t, handler, "GET", "/projects/Missing.cpr", nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(
+		t, map[string]string{"error": "project not found"}, decodeResponse[map[string]string](t, w),
+	)
+}
+
+func TestServePlugins(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestInventory(t).handler("127.0.0.1:8080")
+
+	w := serveRequest(t, handler, "GET", "/plugins", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	plugins := decodeResponse[[]scan.PluginCount](t, w)
+	require.NotEmpty(t, plugins)
+
+	var elephant scan.PluginCount
+	for _, plugin := range plugins {
+		if plugin.Name == "Elephant" {
+			elephant = plugin
+		}
+	}
+
+	require.Equal(
+		t,
+		scan.PluginCount{
+			GUID:    "1C3A662167D347A99F7D797EA4911CDB",
+			Name:    "Elephant",
+			Format:  "vst3",
+			Count32: 1,
+			Count64: 1,
+			Count:   2,
+		},
+		elephant,
+	)
+
+	w = serveRequest(
+		t, handler, "GET", "/plugins/"+strings.ToLower(elephant.GUID)+"/projects", nil,
+	)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, decodeResponse[[]jsonProject](t, w), 2)
+
+	w = serveRequest(t, handler, "GET", "/plugins/UNKNOWN/projects", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, decodeResponse[[]jsonProject](t, w))
+}
+
+func TestServeSummary(t *testing.T) {
+	t.Parallel()
+
+	w := serveRequest(t, newTestInventory(t).handler("127.0.0.1:8080"), "GET", "/summary", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	status := decodeResponse[serveStatus](t, w)
+	require.Equal(t, 2, status.Projects)
+	require.Len(t, status.Failures, 1)
+	require.Len(t, status.Warnings, 1)
+}
+
+func TestServeRefresh(t *testing.T) {
+	t.Parallel()
+
+	// The inventory is refreshed using an empty directory so that the refreshed results differ
+	// from those of the test projects.
+	inventory := newTestInventory(t)
+	inventory.paths = []string{t.TempDir()}
+	handler := inventory.handler("127.0.0.1:8080")
+
+	w := serveRequest(t, handler, "POST", "/refresh", nil)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Equal(t, 2, inventory.projectCount())
+
+	w = serveRequest(t, handler, "GET", "/refresh", http.Header{"X-Requested-With": {"test"}})
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = serveRequest(t, handler, "POST", "/refresh", http.Header{"X-Requested-With": {"test"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 0, decodeResponse[serveStatus](t, w).Projects)
+	require.Equal(t, 0, inventory.projectCount())
+}
+
+func TestServeHostCheck(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		address string
+		host    string
+		allowed bool
+	}{
+		{address: "127.0.0.1:8080", host: "127.0.0.1:8080", allowed: true},
+		{address: "127.0.0.1:8080", host: "localhost:8080", allowed: true},
+		{address: "127.0.0.1:8080", host: "LOCALHOST", allowed: true},
+		{address: "127.0.0.1:8080", host: "[::1]:8080", allowed: true},
+		{address: "127.0.0.1:8080", host: "evil.example.com:8080", allowed: false},
+		{address: "127.0.0.1:8080", host: "192.168.1.10:8080", allowed: false},
+		{address: "studio.local:8080", host: "Studio.local:8080", allowed: true},
+		{address: "studio.local:8080", host: "evil.example.com", allowed: false},
+		{address: "192.168.1.10:8080", host: "192.168.1.10:8080", allowed: true},
+		{address: "0.0.0.0:8080", host: "192.168.1.10:8080", allowed: true},
+		{address: ":8080", host: "[fe80::1]:8080", allowed: true},
+		{address: ":8080", host: "evil.example.com:8080", allowed: false},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest("GET", "/summary", nil)
+		r.Host = tc.host
+
+		w := httptest.NewRecorder()
+		serveHostCheck(tc.address, ok).ServeHTTP(w, r)
+
+		if tc.allowed {
+			require.Equal(t, http.StatusOK, w.Code, "%s via %s", tc.host, tc.address)
+		} else {
+			require.Equal(t, http.StatusForbidden, w.Code, "%s via %s", tc.host, tc.address)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/snapshot"
+)
+
+var snapshotDiffFormat string
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Saves and compares snapshots of the plugins used in your projects.",
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save [flags] <snapshot file> [project path]...",
+	Short: "Scans projects and saves the results to a snapshot file.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		snapshotPath, projectPaths := args[0], args[1:]
+
+		report, err := newScanner(config, projectCache).Scan(cmd.Context(), projectPaths)
+		if err != nil {
+			return err
+		}
+
+		if err := snapshot.New(report, projectPaths, time.Now()).Save(snapshotPath); err != nil {
+			return err
+		}
+
+		heading := color.New(color.BgRed, color.FgHiWhite)
+
+		fmt.Println()
+		heading.Printf("Saved %d projects to %s", len(report.Results), snapshotPath)
+		fmt.Println()
+
+		printFailures(os.Stdout, heading, report.Failures)
+		printWarnings(os.Stdout, heading, report.Warnings)
+
+		return scanError(report.Failures, report.Warnings)
+	},
+}
+
+var snapshotDiffCmd = &cobra.Command{
+	Use:   "diff [flags] <old snapshot file> <new snapshot file>",
+	Short: "Compares two snapshot files.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if snapshotDiffFormat != FormatText && snapshotDiffFormat != FormatJSON {
+			return fmt.Errorf("%w: %s", ErrUnknownFormat, snapshotDiffFormat)
+		}
+
+		oldSnapshot, err := snapshot.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		newSnapshot, err := snapshot.Load(args[1])
+		if err != nil {
+			return err
+		}
+
+		comparison := snapshot.Compare(oldSnapshot, newSnapshot)
+
+		if snapshotDiffFormat == FormatJSON {
+			return writeJSONSnapshotComparison(os.Stdout, oldSnapshot, newSnapshot, comparison)
+		}
+
+		printSnapshotComparison(os.Stdout, oldSnapshot, newSnapshot, comparison)
+
+		return nil
+	},
+}
+
+func init() {
+	snapshotDiffCmd.Flags().
+		StringVarP(&snapshotDiffFormat, "format", "f", FormatText, "output `format` (text or json)")
+
+	addScanFlags(snapshotSaveCmd)
+	addStrictFlag(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotDiffCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// The JSON document produced when comparing two snapshots.
+type jsonSnapshotComparison struct {
+	SchemaVersion int       `json:"schema_version"` // version of the document structure
+	OldCreatedAt  time.Time `json:"old_created_at"` // time the old snapshot was taken
+	NewCreatedAt  time.Time `json:"new_created_at"` // time the new snapshot was taken
+	snapshot.Comparison
+}
+
+func writeJSONSnapshotComparison(
+	w io.Writer,
+	oldSnapshot, newSnapshot *snapshot.Snapshot,
+	comparison snapshot.Comparison,
+) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(jsonSnapshotComparison{
+		SchemaVersion: JSONSchemaVersion,
+		OldCreatedAt:  oldSnapshot.CreatedAt,
+		NewCreatedAt:  newSnapshot.CreatedAt,
+		Comparison:    comparison,
+	})
+}
+
+func printSnapshotComparison(
+	w io.Writer,
+	oldSnapshot, newSnapshot *snapshot.Snapshot,
+	c snapshot.Comparison,
+) {
+	heading := color.New(color.BgRed, color.FgHiWhite)
+	subHeading := color.New(color.FgHiBlue)
+
+	fmt.Fprintln(w)
+	heading.Fprintf(
+		w,
+		"Old: %s (%d projects)",
+		oldSnapshot.CreatedAt.Format(time.DateTime),
+		len(oldSnapshot.Projects),
+	)
+	fmt.Fprintln(w)
+	heading.Fprintf(
+		w,
+		"New: %s (%d projects)",
+		newSnapshot.CreatedAt.Format(time.DateTime),
+		len(newSnapshot.Projects),
+	)
+	fmt.Fprintln(w)
+
+	if !c.HasChanges() {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "The snapshots contain the same projects and plugins.")
+	}
+
+	printDiffSection(w, subHeading, "Added Projects", len(c.AddedProjects), func() {
+		for _, project := range c.AddedProjects {
+			fmt.Fprintf(w, "    + %s (%d plugins)\n", project.Path, len(project.Plugins))
+		}
+	})
+	printDiffSection(w, subHeading, "Removed Projects", len(c.RemovedProjects), func() {
+		for _, project := range c.RemovedProjects {
+			fmt.Fprintf(w, "    - %s (%d plugins)\n", project.Path, len(project.Plugins))
+		}
+	})
+	printDiffSection(w, subHeading, "Changed Projects", len(c.ChangedProjects), func() {
+		for _, change := range c.ChangedProjects {
+			fmt.Fprintf(w, "    ~ %s\n", change.Path)
+
+			for _, metadata := range change.Diff.Metadata {
+				fmt.Fprintf(
+					w, "        ~ %s : %s -> %s\n", metadata.Field, metadata.Old, metadata.New,
+				)
+			}
+
+			for _, plugin := range change.Diff.Added {
+				fmt.Fprintf(
+					w,
+					"        + %s : %s : %s\n",
+					plugin.GUID,
+					formatLabel(plugin.Format),
+					plugin.Name,
+				)
+			}
+
+			for _, plugin := range change.Diff.Removed {
+				fmt.Fprintf(
+					w,
+					"        - %s : %s : %s\n",
+					plugin.GUID,
+					formatLabel(plugin.Format),
+					plugin.Name,
+				)
+			}
+
+			for _, rename := range change.Diff.Renamed {
+				fmt.Fprintf(
+					w,
+					"        ~ %s : %s : %s -> %s\n",
+					rename.GUID,
+					formatLabel(rename.Format),
+					rename.OldName,
+					rename.NewName,
+				)
+			}
+		}
+	})
+	printDiffSection(w, subHeading, "Plugin Usage Changes", len(c.UsageChanges), func() {
+		for _, usage := range c.UsageChanges {
+			fmt.Fprintf(
+				w,
+				"    %s %s : %s : %s (%d -> %d)\n",
+				usageSymbol(usage.Delta()),
+				usage.GUID,
+				formatLabel(usage.Format),
+				usage.Name,
+				usage.OldCount,
+				usage.NewCount,
+			)
+		}
+	})
+}
+
+// usageSymbol returns the symbol indicating whether the usage of a plugin rose or fell.
+func usageSymbol(delta int) string {
+	if delta > 0 {
+		return "+"
+	}
+
+	return "-"
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var ErrParseTemplateFile = errors.New("unable to parse the template file requested")
+
+// The data model made available to user-supplied templates.
+type templateData struct {
+	Projects []templateProject // all projects scanned in the order they were scanned
+	Summary  scan.Summary      // plugin usage by project architecture
+}
+
+// A project made available to user-supplied templates.
+type templateProject struct {
+	Path     string          // path to the project file
+	Metadata parser.Metadata // details about the Cubase version used
+	Plugins  []parser.Plugin // plugins used after ignores are applied, in the --order requested
+	Is64Bit  bool            // whether the project was created on a 64-bit version of Cubase
+
+	Occurrences []parser.Occurrence   // every plugin instance in the order they appear
+	Instances   map[parser.Plugin]int // number of instances of each plugin in the project
+}
+
+// The helper functions made available to user-supplied templates.
+var templateFuncs = template.FuncMap{
+	"lower":         strings.ToLower,
+	"upper":         strings.ToUpper,
+	"join":          strings.Join,
+	"names":         pluginNames,
+	"guids":         pluginGUIDs,
+	"sortByName":    sortedByName,
+	"sortByGUID":    sortedByGUID,
+	"pluginsByName": parser.SortedPlugins,
+	"pluginsByUse":  sortedByUse,
+	"formatCount":   formatCount,
+	"plural":        plural,
+}
+
+// Renders all scan results using a user-supplied text template once scanning has completed.
+type templateFormatter struct {
+	stderrProblems
+
+	w        io.Writer
+	tmpl     *template.Template
+	projects []templateProject
+}
+
+func newTemplateFormatter(w io.Writer, path string) (*templateFormatter, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseTemplateFile, err)
+	}
+
+	return &templateFormatter{w: w, tmpl: tmpl}, nil
+}
+
+func (f *templateFormatter) Project(result scan.Result) error {
+	f.projects = append(f.projects, templateProject{
+		Path:     result.Path,
+		Metadata: result.Project.Metadata,
+		Plugins:  result.Plugins,
+		Is64Bit:  result.Is64Bit,
+
+		Occurrences: result.Project.Occurrences,
+		Instances:   result.Project.InstanceCounts(),
+	})
+
+	return nil
+}
+
+func (f *templateFormatter) Summary(summary scan.Summary) error {
+	return f.tmpl.Execute(f.w, templateData{Projects: f.projects, Summary: summary})
+}
+
+// pluginNames returns the names of the plugins provided.
+func pluginNames(plugins []parser.Plugin) []string {
+	names := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name)
+	}
+
+	return names
+}
+
+// pluginGUIDs returns the GUIDs of the plugins provided.
+func pluginGUIDs(plugins []parser.Plugin) []string {
+	guids := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		guids = append(guids, plugin.GUID)
+	}
+
+	return guids
+}
+
+// sortedByName returns a copy of the plugins provided sorted by name.
+func sortedByName(plugins []parser.Plugin) []parser.Plugin {
+	sorted := slices.Clone(plugins)
+	parser.SortPluginsByName(sorted)
+
+	return sorted
+}
+
+// sortedByGUID returns a copy of the plugins provided sorted by GUID.
+func sortedByGUID(plugins []parser.Plugin) []parser.Plugin {
+	sorted := slices.Clone(plugins)
+	parser.SortPluginsByGUID(sorted)
+
+	return sorted
+}
+
+// sortedByUse returns the plugins in the counts map provided from most to least used.
+func sortedByUse(pluginCounts map[parser.Plugin]int) []parser.Plugin {
+	return matrixPlugins(pluginCounts, 0)
+}
+
+// formatCount formats the count provided with thousands separators.
+func formatCount(count int) string {
+	digits := strconv.Itoa(count)
+
+	sign := ""
+	if count < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(',')
+		}
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String()
+}
+
+// plural returns the singular form provided when the count is one and the plural form otherwise.
+func plural(count int, singular, pluralForm string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return pluralForm
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	t.Parallel()
+
+	templatePath := filepath.Join(t.TempDir(), "report.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte(
+		`{{ range .Projects }}{{ .Metadata.Version }} {{ .Is64Bit }} `+
+			`{{ join (names (sortByGUID .Plugins)) ", " }}
+{{ end }}{{ range pluginsByUse .Summary.PluginCounts }}{{ upper .Name }} {{ .Format }}
+{{ end }}`,
+	), 0o644))
+
+	var b bytes.Buffer
+	out, err := newTemplateFormatter(&b, templatePath)
+	require.NoError(t, err)
+
+	renderFormatter(t, out)
+	requireGolden(t, "template", b.Bytes())
+}
+
+func TestTemplateFormatterInvalid(t *testing.T) {
+	t.Parallel()
+
+	templatePath := filepath.Join(t.TempDir(), "report.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("{{ .Projects"), 0o644))
+
+	_, err := newTemplateFormatter(&bytes.Buffer{}, templatePath)
+	require.ErrorIs(t, err, ErrParseTemplateFile)
+
+	_, err = newTemplateFormatter(&bytes.Buffer{}, filepath.Join(t.TempDir(), "missing.tmpl"))
+	require.ErrorIs(t, err, ErrParseTemplateFile)
+}
+
+func TestFormatCount(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[int]string{
+		0:        "0",
+		7:        "7",
+		999:      "999",
+		1000:     "1,000",
+		12345:    "12,345",
+		1234567:  "1,234,567",
+		-1234567: "-1,234,567",
+		-100:     "-100",
+	}
+
+	for count, expected := range testCases {
+		require.Equal(t, expected, formatCount(count), count)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "project", plural(1, "project", "projects"))
+	require.Equal(t, "projects", plural(0, "project", "projects"))
+	require.Equal(t, "projects", plural(2, "project", "projects"))
+}
+
+func TestTemplatePluginHelpers(t *testing.T) {
+	t.Parallel()
+
+	elephant := parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	eq := parser.Plugin{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"}
+	plugins := []parser.Plugin{eq, elephant}
+
+	require.Equal(t, []string{"EQ", "Elephant"}, pluginNames(plugins))
+	require.Equal(
+		t,
+		[]string{"297BA567D83144E1AE921DEF07B41156", "1C3A662167D347A99F7D797EA4911CDB"},
+		pluginGUIDs(plugins),
+	)
+	require.Equal(t, []parser.Plugin{elephant, eq}, sortedByName(plugins))
+	require.Equal(t, []parser.Plugin{elephant, eq}, sortedByGUID(plugins))
+	require.Equal(t, []parser.Plugin{eq, elephant}, plugins)
+	require.Equal(
+		t, []parser.Plugin{eq, elephant}, sortedByUse(map[parser.Plugin]int{elephant: 1, eq: 2}),
+	)
+}
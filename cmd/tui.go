@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var ErrNoConfigPath = errors.New("unable to determine the path of the config file")
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [flags] [project path]...",
+	Short: "Browses the projects and plugins scanned in an interactive terminal interface.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		fmt.Println("Scanning projects...")
+
+		report, err := newScanner(config, projectCache).Scan(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+
+		activeConfigPath := configPath
+		if activeConfigPath == "" {
+			activeConfigPath = getDefaultConfigPath()
+		}
+
+		program := tea.NewProgram(
+			newTUIModel(report, activeConfigPath),
+			tea.WithAltScreen(),
+			tea.WithContext(cmd.Context()),
+		)
+
+		if _, err := program.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	addScanFlags(tuiCmd)
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// The kinds of list which may be browsed in the terminal interface.
+type tuiView int
+
+const (
+	tuiViewProjects       tuiView = iota // all projects
+	tuiViewPlugins                       // all plugins along with their usage counts
+	tuiViewPluginProjects                // the projects using a particular plugin
+	tuiViewProjectPlugins                // the plugins used by a particular project
+)
+
+// A list being browsed in the terminal interface.  Screens are stacked as the user drills down so
+// that returning to a previous screen restores its position and search.
+type tuiScreen struct {
+	view   tuiView
+	plugin parser.Plugin // plugin being viewed for the plugin projects view
+	path   string        // project being viewed for the project plugins view
+	query  string        // search which rows must contain
+	cursor int           // index of the selected row
+	offset int           // index of the first row displayed
+}
+
+// A row of a list which refers to either a project or a plugin.
+type tuiRow struct {
+	label  string
+	path   string
+	plugin *parser.Plugin
+}
+
+// The state of the terminal interface.
+type tuiModel struct {
+	results    []scan.Result
+	summary    scan.Summary
+	failures   int
+	configPath string // config file that ignored plugins are added to
+	screens    []tuiScreen
+	searching  bool
+	status     string
+	width      int
+	height     int
+
+	heading    *color.Color
+	subHeading *color.Color
+	selected   *color.Color
+}
+
+func newTUIModel(report *scan.Report, configPath string) *tuiModel {
+	return &tuiModel{
+		results:    report.Results,
+		summary:    report.Summary,
+		failures:   len(report.Failures),
+		configPath: configPath,
+		screens:    []tuiScreen{{view: tuiViewProjects}},
+		heading:    color.New(color.BgRed, color.FgHiWhite),
+		subHeading: color.New(color.FgHiBlue),
+		selected:   color.New(color.ReverseVideo),
+	}
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
+
+		return m, m.updateBrowse(msg)
+	}
+
+	return m, nil
+}
+
+// updateSearch handles keys while a search is being typed, filtering the rows as each key is
+// pressed.
+func (m *tuiModel) updateSearch(msg tea.KeyMsg) {
+	screen := m.screen()
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+	case tea.KeyEsc:
+		m.searching = false
+		screen.query = ""
+	case tea.KeyBackspace:
+		if query := []rune(screen.query); len(query) > 0 {
+			screen.query = string(query[:len(query)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		screen.query += string(msg.Runes)
+	}
+
+	screen.cursor = 0
+	screen.offset = 0
+}
+
+// updateBrowse handles keys while a list is being browsed.
+func (m *tuiModel) updateBrowse(msg tea.KeyMsg) tea.Cmd {
+	screen := m.screen()
+	rows := m.rows()
+	m.status = ""
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return tea.Quit
+	case "up", "k":
+		screen.cursor--
+	case "down", "j":
+		screen.cursor++
+	case "pgup":
+		screen.cursor -= m.listHeight()
+	case "pgdown":
+		screen.cursor += m.listHeight()
+	case "home", "g":
+		screen.cursor = 0
+	case "end", "G":
+		screen.cursor = len(rows) - 1
+	case "tab":
+		view := tuiViewPlugins
+		if m.screens[0].view == tuiViewPlugins {
+			view = tuiViewProjects
+		}
+
+		m.screens = []tuiScreen{{view: view}}
+	case "enter", "right", "l":
+		if screen.cursor < len(rows) {
+			m.drillDown(rows[screen.cursor])
+		}
+	case "esc", "backspace", "left", "h":
+		if screen.query != "" {
+			screen.query = ""
+		} else if len(m.screens) > 1 {
+			m.screens = m.screens[:len(m.screens)-1]
+		}
+	case "/":
+		m.searching = true
+	case "i":
+		if screen.cursor < len(rows) && rows[screen.cursor].plugin != nil {
+			m.ignorePlugin(*rows[screen.cursor].plugin)
+		}
+	}
+
+	m.clampCursor()
+
+	return nil
+}
+
+// drillDown displays the projects using the plugin or the plugins used by the project in the row
+// provided.
+func (m *tuiModel) drillDown(row tuiRow) {
+	if row.plugin != nil {
+		m.screens = append(m.screens, tuiScreen{view: tuiViewPluginProjects, plugin: *row.plugin})
+	} else {
+		m.screens = append(m.screens, tuiScreen{view: tuiViewProjectPlugins, path: row.path})
+	}
+}
+
+// ignorePlugin adds the plugin provided to the ignored GUIDs in the config file and removes it
+// from the results being browsed.
+func (m *tuiModel) ignorePlugin(plugin parser.Plugin) {
+	if m.configPath == "" {
+		m.status = ErrNoConfigPath.Error()
+		return
+	}
+
+	if err := config.AddGUIDIgnore(m.configPath, plugin.GUID); err != nil {
+		m.status = err.Error()
+		return
+	}
+
+	m.summary = scan.NewSummary()
+	for i, result := range m.results {
+		result.Plugins = slices.DeleteFunc(
+			slices.Clone(result.Plugins),
+			func(p parser.Plugin) bool { return p.GUID == plugin.GUID },
+		)
+		m.results[i] = result
+		m.summary.Add(result)
+	}
+
+	// Screens for the ignored plugin no longer have anything to display.
+	m.screens = slices.DeleteFunc(m.screens, func(screen tuiScreen) bool {
+		return screen.view == tuiViewPluginProjects && screen.plugin.GUID == plugin.GUID
+	})
+
+	m.status = fmt.Sprintf(
+		"Added %s (%s) to guid_ignores in %s", plugin.GUID, plugin.Name, m.configPath,
+	)
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+
+	screen := m.screen()
+	rows := m.rows()
+
+	b.WriteString(m.heading.Sprint(m.truncate(m.title())))
+	b.WriteString("\n\n")
+
+	end := min(screen.offset+m.listHeight(), len(rows))
+	for i := screen.offset; i < end; i++ {
+		label := m.truncate("  " + rows[i].label)
+		if i == screen.cursor {
+			label = m.selected.Sprint(label)
+		}
+
+		b.WriteString(label)
+		b.WriteString("\n")
+	}
+
+	for i := end - screen.offset; i < m.listHeight(); i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	switch {
+	case m.searching:
+		b.WriteString(m.truncate("Search: " + screen.query + "_"))
+	case m.status != "":
+		b.WriteString(m.subHeading.Sprint(m.truncate(m.status)))
+	default:
+		b.WriteString(m.subHeading.Sprint(m.truncate(
+			"enter: open  esc: back  tab: projects/plugins  /: search  i: ignore plugin  q: quit",
+		)))
+	}
+
+	return b.String()
+}
+
+// screen returns the screen currently being displayed.
+func (m *tuiModel) screen() *tuiScreen {
+	return &m.screens[len(m.screens)-1]
+}
+
+// title returns the heading of the screen currently being displayed.
+func (m *tuiModel) title() string {
+	screen := m.screen()
+
+	var title string
+	switch screen.view {
+	case tuiViewProjects:
+		title = fmt.Sprintf("Projects (%d scanned, %d failed)", len(m.results), m.failures)
+	case tuiViewPlugins:
+		title = fmt.Sprintf("Plugins (%d)", len(m.summary.PluginCounts))
+	case tuiViewPluginProjects:
+		title = fmt.Sprintf(
+			"Plugin: %s : %s : %s",
+			screen.plugin.GUID,
+			formatLabel(screen.plugin.Format()),
+			screen.plugin.Name,
+		)
+	case tuiViewProjectPlugins:
+		title = "Path: " + screen.path
+	}
+
+	if screen.query != "" && !m.searching {
+		title += fmt.Sprintf(" matching %q", screen.query)
+	}
+
+	return title
+}
+
+// rows returns the rows of the screen currently being displayed which match its search.
+func (m *tuiModel) rows() []tuiRow {
+	screen := m.screen()
+
+	var rows []tuiRow
+	switch screen.view {
+	case tuiViewProjects:
+		for _, result := range m.results {
+			rows = append(rows, m.projectRow(result))
+		}
+	case tuiViewPlugins:
+		for _, plugin := range parser.SortedPlugins(m.summary.PluginCounts) {
+			rows = append(rows, tuiRow{
+				label: fmt.Sprintf(
+					"%s : %s : %s (%d)",
+					plugin.GUID,
+					formatLabel(plugin.Format()),
+					plugin.Name,
+					m.summary.PluginCounts[plugin],
+				),
+				plugin: &plugin,
+			})
+		}
+	case tuiViewPluginProjects:
+		for _, result := range m.results {
+			if slices.Contains(m.summary.PluginProjects[screen.plugin], result.Path) {
+				rows = append(rows, m.projectRow(result))
+			}
+		}
+	case tuiViewProjectPlugins:
+		for _, result := range m.results {
+			if result.Path != screen.path {
+				continue
+			}
+
+			for _, plugin := range result.Plugins {
+				rows = append(rows, tuiRow{
+					label: fmt.Sprintf(
+						"%s : %s : %s", plugin.GUID, formatLabel(plugin.Format()), plugin.Name,
+					),
+					plugin: &plugin,
+				})
+			}
+		}
+	}
+
+	if screen.query == "" {
+		return rows
+	}
+
+	query := strings.ToLower(screen.query)
+
+	return slices.DeleteFunc(rows, func(row tuiRow) bool {
+		return !strings.Contains(strings.ToLower(row.label), query)
+	})
+}
+
+func (m *tuiModel) projectRow(result scan.Result) tuiRow {
+	return tuiRow{
+		label: fmt.Sprintf(
+			"%s (%s %s, %s, %d plugins)",
+			result.Path,
+			result.Project.Metadata.Application,
+			result.Project.Metadata.Version,
+			result.Project.Metadata.Architecture,
+			len(result.Plugins),
+		),
+		path: result.Path,
+	}
+}
+
+// listHeight returns the number of rows which fit on the screen between the heading and the help.
+func (m *tuiModel) listHeight() int {
+	return max(m.height-4, 1)
+}
+
+// clampCursor keeps the cursor within the rows of the screen and scrolls it into view.
+func (m *tuiModel) clampCursor() {
+	screen := m.screen()
+	rows := m.rows()
+
+	screen.cursor = max(min(screen.cursor, len(rows)-1), 0)
+
+	if screen.cursor < screen.offset {
+		screen.offset = screen.cursor
+	} else if screen.cursor >= screen.offset+m.listHeight() {
+		screen.offset = screen.cursor - m.listHeight() + 1
+	}
+}
+
+// truncate shortens the text provided so that it fits within the width of the terminal.
+func (m *tuiModel) truncate(text string) string {
+	runes := []rune(text)
+	if m.width <= 0 || len(runes) <= m.width {
+		return text
+	}
+
+	return string(runes[:m.width])
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+var tuiElephant = parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+
+// pressKeys sends a key message to the model for each of the keys provided.
+func pressKeys(m *tuiModel, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+
+		_, cmd = m.Update(msg)
+	}
+
+	return cmd
+}
+
+// rowLabels returns the labels of the rows currently being displayed.
+func rowLabels(m *tuiModel) []string {
+	rows := m.rows()
+
+	labels := make([]string, 0, len(rows))
+	for _, row := range rows {
+		labels = append(labels, row.label)
+	}
+
+	return labels
+}
+
+// rowIndex returns the index of the first row currently being displayed which contains the text
+// provided.
+func rowIndex(t *testing.T, m *tuiModel, text string) int {
+	t.Helper()
+
+	for i, label := range rowLabels(m) {
+		if strings.Contains(label, text) {
+			return i
+		}
+	}
+
+	require.Failf(t, "row not found", "no row contains %q", text)
+
+	return -1
+}
+
+func newTestTUIModel(t *testing.T, configPath string) *tuiModel {
+	t.Helper()
+
+	m := newTUIModel(scanTestProjects(t), configPath)
+	m.Update(tea.WindowSizeMsg{Width: 200, Height: 10})
+
+	return m
+}
+
+func TestTUIProjects(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	require.Equal(t, "Projects (2 scanned, 1 failed)", m.title())
+	require.Equal(
+		t,
+		[]string{
+			testProjectPaths[0] + " (Cubase 5.5.3, WIN32, 10 plugins)",
+			testProjectPaths[1] + " (Cubase 13.0.10, WIN64, 12 plugins)",
+		},
+		rowLabels(m),
+	)
+
+	pressKeys(m, "down", "enter")
+	require.Equal(t, tuiViewProjectPlugins, m.screen().view)
+	require.Equal(t, "Path: "+testProjectPaths[1], m.title())
+	require.Len(t, m.rows(), 12)
+	require.Contains(t, rowLabels(m), tuiElephant.GUID+" : VST3 : Elephant")
+
+	// Returning to the projects restores the position of the cursor.
+	pressKeys(m, "esc")
+	require.Equal(t, tuiViewProjects, m.screen().view)
+	require.Equal(t, 1, m.screen().cursor)
+}
+
+func TestTUIPlugins(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	pressKeys(m, "tab")
+	require.Equal(t, tuiViewPlugins, m.screen().view)
+	require.Equal(t, "Plugins (13)", m.title())
+	require.Contains(t, rowLabels(m), tuiElephant.GUID+" : VST3 : Elephant (2)")
+
+	m.screen().cursor = rowIndex(t, m, "Elephant")
+	pressKeys(m, "enter")
+	require.Equal(t, tuiViewPluginProjects, m.screen().view)
+	require.Equal(t, tuiElephant, m.screen().plugin)
+	require.Len(t, m.rows(), 2)
+
+	pressKeys(m, "tab")
+	require.Len(t, m.screens, 1)
+	require.Equal(t, tuiViewProjects, m.screen().view)
+}
+
+func TestTUINavigation(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	pressKeys(m, "tab")
+	rows := len(m.rows())
+
+	pressKeys(m, "up")
+	require.Equal(t, 0, m.screen().cursor)
+
+	pressKeys(m, "j", "j")
+	require.Equal(t, 2, m.screen().cursor)
+
+	pressKeys(m, "k")
+	require.Equal(t, 1, m.screen().cursor)
+
+	// The list scrolls so that the cursor is always displayed within the 6 rows which fit.
+	pressKeys(m, "G")
+	require.Equal(t, rows-1, m.screen().cursor)
+	require.Equal(t, rows-6, m.screen().offset)
+
+	pressKeys(m, "down")
+	require.Equal(t, rows-1, m.screen().cursor)
+
+	pressKeys(m, "g")
+	require.Equal(t, 0, m.screen().cursor)
+	require.Equal(t, 0, m.screen().offset)
+}
+
+func TestTUISearch(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	pressKeys(m, "tab", "/", "E", "L", "E", "P", "x", "backspace")
+	require.True(t, m.searching)
+	require.Contains(t, m.View(), "Search: ELEP_")
+	require.Equal(t, []string{tuiElephant.GUID + " : VST3 : Elephant (2)"}, rowLabels(m))
+
+	pressKeys(m, "enter")
+	require.False(t, m.searching)
+	require.Equal(t, `Plugins (13) matching "ELEP"`, m.title())
+
+	pressKeys(m, "esc")
+	require.Empty(t, m.screen().query)
+	require.Len(t, m.rows(), 13)
+
+	// Cancelling a search clears it.
+	pressKeys(m, "/", "q", "esc")
+	require.False(t, m.searching)
+	require.Empty(t, m.screen().query)
+}
+
+func TestTUIIgnorePlugin(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	m := newTestTUIModel(t, configPath)
+	pressKeys(m, "tab")
+	m.screen().cursor = rowIndex(t, m, "Elephant")
+	pressKeys(m, "enter", "esc", "i")
+
+	require.Equal(
+		t, "Added "+tuiElephant.GUID+" (Elephant) to guid_ignores in "+configPath, m.status,
+	)
+	require.Equal(t, "Plugins (12)", m.title())
+	require.NotContains(t, strings.Join(rowLabels(m), "\n"), "Elephant")
+
+	pressKeys(m, "tab")
+	require.Contains(t, rowLabels(m)[0], "9 plugins")
+	require.Contains(t, rowLabels(m)[1], "11 plugins")
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.Contains(t, string(data), tuiElephant.GUID)
+}
+
+func TestTUIIgnorePluginNoConfigPath(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	pressKeys(m, "tab", "i")
+	require.Equal(t, ErrNoConfigPath.Error(), m.status)
+	require.Equal(t, "Plugins (13)", m.title())
+	require.Contains(t, m.View(), ErrNoConfigPath.Error())
+}
+
+func TestTUIQuit(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	require.Nil(t, pressKeys(m, "j"))
+
+	cmd := pressKeys(m, "q")
+	require.NotNil(t, cmd)
+	require.Equal(t, tea.QuitMsg{}, cmd())
+}
+
+func TestTUIView(t *testing.T) {
+	t.Parallel()
+
+	m := newTestTUIModel(t, "")
+	m.Update(tea.WindowSizeMsg{Width: 20, Height: 6})
+
+	lines := strings.Split(m.View(), "\n")
+	require.Len(t, lines, 6)
+	require.Contains(t, lines[0], "Projects (2 scanne")
+	require.Contains(t, lines[2], "  ../parser/testdat")
+}
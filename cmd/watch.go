@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var (
+	watchInterval time.Duration
+	watchDebounce time.Duration
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [flags] [project path]...",
+	Short: "Watches projects for changes and displays their plugins as they are saved.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		watcher := newProjectWatcher(newScanner(config, projectCache), args)
+
+		return watcher.run(cmd.Context())
+	},
+}
+
+func init() {
+	watchCmd.Flags().
+		DurationVar(&watchInterval, "interval", 2*time.Second, "how often to check for changes")
+	watchCmd.Flags().
+		DurationVar(
+			&watchDebounce,
+			"debounce",
+			3*time.Second,
+			"how long a project must remain unchanged before it is parsed",
+		)
+
+	addScanFlags(watchCmd)
+	rootCmd.AddCommand(watchCmd)
+}
+
+// The size and modification time of a project file used to detect changes.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// equal determines whether two file states are the same.  Modification times are compared using
+// time.Time.Equal since the same instant may have a different location or monotonic reading.
+func (s fileState) equal(other fileState) bool {
+	return s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// Watches project paths for changes by periodically polling them, which works reliably on both
+// local disks and network shares.  Projects are only parsed again once they have remained
+// unchanged for the debounce period, since Cubase writes project files in several bursts when
+// saving.
+type projectWatcher struct {
+	scanner *scan.Scanner
+	paths   []string
+	files   map[string]fileState
+	pending map[string]time.Time // time each changed project was last seen changing
+	results map[string]scan.Result
+}
+
+func newProjectWatcher(scanner *scan.Scanner, paths []string) *projectWatcher {
+	return &projectWatcher{
+		scanner: scanner,
+		paths:   paths,
+		files:   make(map[string]fileState),
+		pending: make(map[string]time.Time),
+		results: make(map[string]scan.Result),
+	}
+}
+
+// run performs an initial scan of all projects and then displays projects as they change until
+// the context is cancelled.
+func (w *projectWatcher) run(ctx context.Context) error {
+	w.files = w.snapshot()
+
+	report, err := w.scanner.Scan(ctx, w.paths)
+	if err != nil {
+		return ignoreCancelled(err)
+	}
+
+	for _, result := range report.Results {
+		w.results[result.Path] = result
+	}
+
+	if err := w.print(nil, nil, report); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.poll(ctx); err != nil {
+				return ignoreCancelled(err)
+			}
+		}
+	}
+}
+
+// poll checks for changes to project files and parses those which are ready.
+func (w *projectWatcher) poll(ctx context.Context) error {
+	now := time.Now()
+	current := w.snapshot()
+
+	for path, state := range current {
+		if previous, ok := w.files[path]; !ok || !previous.equal(state) {
+			w.pending[path] = now
+		}
+	}
+
+	var removed []string
+	for path := range w.files {
+		if _, ok := current[path]; !ok {
+			removed = append(removed, path)
+			delete(w.pending, path)
+			delete(w.results, path)
+		}
+	}
+
+	w.files = current
+
+	var ready []string
+	for path, changed := range w.pending {
+		if now.Sub(changed) >= watchDebounce {
+			ready = append(ready, path)
+			delete(w.pending, path)
+		}
+	}
+
+	if len(ready) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	slices.Sort(ready)
+	slices.Sort(removed)
+
+	report := &scan.Report{}
+	if len(ready) > 0 {
+		var err error
+		if report, err = w.scanner.Scan(ctx, ready); err != nil {
+			return err
+		}
+	}
+
+	// Projects which are no longer reported (e.g. they now fail to parse) are also removed.
+	for _, path := range ready {
+		delete(w.results, path)
+	}
+
+	for _, result := range report.Results {
+		w.results[result.Path] = result
+	}
+
+	return w.print(ready, removed, report)
+}
+
+// snapshot returns the state of every project file in the watched paths which isn't ignored.
+func (w *projectWatcher) snapshot() map[string]fileState {
+	files := make(map[string]fileState)
+
+	for _, projectPath := range w.paths {
+		_ = filepath.Walk(projectPath, func(path string, info fs.FileInfo, err error) error {
+			if err != nil || info.IsDir() || filepath.Ext(path) != ".cpr" ||
+				w.scanner.IsPathIgnored(path) {
+				return nil
+			}
+
+			files[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+
+			return nil
+		})
+	}
+
+	return files
+}
+
+// print displays the projects which changed in the report provided followed by the summary of
+// all projects being watched.
+func (w *projectWatcher) print(changed, removed []string, report *scan.Report) error {
+	out := newTextFormatter(os.Stdout, outputOptions{})
+	heading := color.New(color.BgRed, color.FgHiWhite)
+
+	if changed != nil || removed != nil {
+		fmt.Println()
+		heading.Printf("Changes Detected At %s", time.Now().Format(time.TimeOnly))
+		fmt.Println()
+	}
+
+	for _, path := range removed {
+		fmt.Println()
+		fmt.Printf("    > Removed: %s\n", path)
+	}
+
+	for _, result := range report.Results {
+		if err := out.Project(result); err != nil {
+			return err
+		}
+	}
+
+	for _, failure := range report.Failures {
+		if err := out.ProjectError(failure); err != nil {
+			return err
+		}
+	}
+
+	for _, warning := range report.Warnings {
+		if err := out.Warning(warning); err != nil {
+			return err
+		}
+	}
+
+	paths := make([]string, 0, len(w.results))
+	for path := range w.results {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	summary := scan.NewSummary()
+	for _, path := range paths {
+		summary.Add(w.results[path])
+	}
+
+	if err := out.Summary(summary); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("Watching %d projects for changes (press Ctrl+C to stop)\n", len(w.files))
+
+	return nil
+}
+
+// ignoreCancelled returns nil when the error provided is due to the context being cancelled,
+// which is the expected way to stop watching.
+func ignoreCancelled(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStateEqual(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	state := fileState{size: 1024, modTime: modTime}
+	sydney := time.FixedZone("AEDT", 11*60*60)
+
+	require.True(t, state.equal(fileState{size: 1024, modTime: modTime.In(sydney)}))
+	require.False(t, state.equal(fileState{size: 2048, modTime: modTime}))
+	require.False(t, state.equal(fileState{size: 1024, modTime: modTime.Add(time.Second)}))
+}
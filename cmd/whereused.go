@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var (
+	ErrNoPluginQuery     = errors.New("at least one --guid, --name or --regex must be specified")
+	ErrInvalidNameGlob   = errors.New("the plugin name pattern requested is invalid")
+	ErrInvalidNameRegexp = errors.New("the plugin name regular expression requested is invalid")
+)
+
+var (
+	whereUsedGUIDs   []string
+	whereUsedNames   []string
+	whereUsedRegexps []string
+)
+
+// A project which uses a plugin that was queried.
+type pluginUsage struct {
+	Path    string          // path to the project file
+	Project *parser.Project // details parsed from the project file
+}
+
+var whereUsedCmd = &cobra.Command{
+	Use:   "where-used [flags] [project path]...",
+	Short: "Lists every project which uses the plugins requested.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if len(whereUsedGUIDs) == 0 && len(whereUsedNames) == 0 && len(whereUsedRegexps) == 0 {
+			return ErrNoPluginQuery
+		}
+
+		matcher, err := newPluginMatcher(whereUsedGUIDs, whereUsedNames, whereUsedRegexps)
+		if err != nil {
+			return err
+		}
+
+		config, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		projectCache := openCache()
+		defer saveCache(projectCache)
+
+		usages := make(map[parser.Plugin][]pluginUsage)
+
+		scanner := newScanner(config, projectCache)
+		scanner.OnResult = func(result scan.Result) error {
+			for _, plugin := range result.Project.Plugins {
+				if matcher.matches(plugin) {
+					usages[plugin] = append(
+						usages[plugin], pluginUsage{Path: result.Path, Project: result.Project},
+					)
+				}
+			}
+
+			return nil
+		}
+
+		report, err := scanner.Scan(cmd.Context(), args)
+		if err != nil {
+			return err
+		}
+
+		heading := color.New(color.BgRed, color.FgHiWhite)
+
+		printPluginUsages(usages)
+		printFailures(os.Stdout, heading, report.Failures)
+		printWarnings(os.Stdout, heading, report.Warnings)
+
+		return scanError(report.Failures, report.Warnings)
+	},
+}
+
+func init() {
+	whereUsedCmd.Flags().
+		StringSliceVarP(&whereUsedGUIDs, "guid", "g", nil, "plugin `GUID` to search for")
+	whereUsedCmd.Flags().
+		StringSliceVarP(
+			&whereUsedNames, "name", "n", nil, "plugin name glob `pattern` to search for",
+		)
+	whereUsedCmd.Flags().
+		StringSliceVarP(
+			&whereUsedRegexps, "regex", "r", nil, "plugin name regular `expression` to search for",
+		)
+
+	addScanFlags(whereUsedCmd)
+	addStrictFlag(whereUsedCmd)
+	rootCmd.AddCommand(whereUsedCmd)
+}
+
+// Determines whether plugins match any of a set of GUIDs, name glob patterns or name regular
+// expressions.  All comparisons are case-insensitive.
+type pluginMatcher struct {
+	guids   []string
+	names   []string
+	regexps []*regexp.Regexp
+}
+
+func newPluginMatcher(guids, names, regexps []string) (*pluginMatcher, error) {
+	matcher := &pluginMatcher{}
+
+	for _, guid := range guids {
+		matcher.guids = append(matcher.guids, strings.ToUpper(guid))
+	}
+
+	for _, name := range names {
+		pattern := strings.ToLower(name)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNameGlob, name)
+		}
+
+		matcher.names = append(matcher.names, pattern)
+	}
+
+	for _, expression := range regexps {
+		re, err := regexp.Compile("(?i)" + expression)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidNameRegexp, err)
+		}
+
+		matcher.regexps = append(matcher.regexps, re)
+	}
+
+	return matcher, nil
+}
+
+func (m *pluginMatcher) matches(plugin parser.Plugin) bool {
+	if slices.Contains(m.guids, strings.ToUpper(plugin.GUID)) {
+		return true
+	}
+
+	name := strings.ToLower(plugin.Name)
+	for _, pattern := range m.names {
+		if match, _ := path.Match(pattern, name); match {
+			return true
+		}
+	}
+
+	for _, re := range m.regexps {
+		if re.MatchString(plugin.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printPluginUsages(usages map[parser.Plugin][]pluginUsage) {
+	heading := color.New(color.BgRed, color.FgHiWhite)
+
+	plugins := make([]parser.Plugin, 0, len(usages))
+	for plugin := range usages {
+		plugins = append(plugins, plugin)
+	}
+
+	parser.SortPluginsByName(plugins)
+
+	for _, plugin := range plugins {
+		projects := usages[plugin]
+		slices.SortFunc(projects, func(a, b pluginUsage) int {
+			return cmp.Compare(a.Path, b.Path)
+		})
+
+		fmt.Println()
+		heading.Printf(
+			"Plugin: %s : %s : %s (%d)",
+			plugin.GUID,
+			formatLabel(plugin.Format()),
+			plugin.Name,
+			len(projects),
+		)
+		fmt.Println()
+		fmt.Println()
+
+		for _, usage := range projects {
+			fmt.Printf(
+				"    > %s (%s %s)\n",
+				usage.Path,
+				usage.Project.Metadata.Application,
+				usage.Project.Metadata.Version,
+			)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestPluginMatcher(t *testing.T) {
+	t.Parallel()
+
+	elephant := parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	omnisphere := parser.Plugin{GUID: "565354414D4D4D6F6D6E697370686572", Name: "Omnisphere"}
+	studioEQ := parser.Plugin{GUID: "946051208E29496E804F64A825C8A047", Name: "StudioEQ"}
+
+	testCases := []struct {
+		name     string
+		guids    []string
+		names    []string
+		regexps  []string
+		expected []parser.Plugin
+	}{
+		{
+			name:     "guid is case-insensitive",
+			guids:    []string{"1c3a662167d347a99f7d797ea4911cdb"},
+			expected: []parser.Plugin{elephant},
+		},
+		{
+			name:     "name glob is case-insensitive",
+			names:    []string{"omni*"},
+			expected: []parser.Plugin{omnisphere},
+		},
+		{
+			name:     "name glob must match the entire name",
+			names:    []string{"eq"},
+			expected: nil,
+		},
+		{
+			name:     "regex is case-insensitive and unanchored",
+			regexps:  []string{"eq$"},
+			expected: []parser.Plugin{studioEQ},
+		},
+		{
+			name:     "any query may match",
+			guids:    []string{omnisphere.GUID},
+			names:    []string{"Studio?Q"},
+			regexps:  []string{"^ele"},
+			expected: []parser.Plugin{elephant, omnisphere, studioEQ},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher, err := newPluginMatcher(tc.guids, tc.names, tc.regexps)
+			require.NoError(t, err)
+
+			var matched []parser.Plugin
+			for _, plugin := range []parser.Plugin{elephant, omnisphere, studioEQ} {
+				if matcher.matches(plugin) {
+					matched = append(matched, plugin)
+				}
+			}
+
+			require.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestPluginMatcherInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := newPluginMatcher(nil, []string{"[eq"}, nil)
+	require.ErrorIs(t, err, ErrInvalidNameGlob)
+
+	_, err = newPluginMatcher(nil, nil, []string{"(eq"})
+	require.ErrorIs(t, err, ErrInvalidNameRegexp)
+}
@@ -2,8 +2,10 @@
 
 // Project specific configuration for the tool.
 type Projects struct {
-	Report32Bit bool `toml:"report_32_bit"` // whether 32-bit projects should be reported.
-	Report64Bit bool `toml:"report_64_bit"` // whether 64-bit projects should be reported.
+	Report32Bit bool   `toml:"report_32_bit"` // whether 32-bit projects should be reported.
+	Report64Bit bool   `toml:"report_64_bit"` // whether 64-bit projects should be reported.
+	MinVersion  string `toml:"min_version"`   // oldest Cubase version of projects to report
+	MaxVersion  string `toml:"max_version"`   // newest Cubase version of projects to report
 }
 
 // Plugin specific configuration for the tool.
@@ -12,9 +14,19 @@ type Plugins struct {
 	NameIgnores []string `toml:"name_ignores"` // plugin names which should be ignored
 }
 
+// Plugin policy enforced by the check command.
+type Policy struct {
+	AllowedGUIDs       []string `toml:"allowed_guids"`        // the only plugin GUIDs permitted
+	DeniedGUIDs        []string `toml:"denied_guids"`         // plugin GUIDs which are forbidden
+	DeniedNamePatterns []string `toml:"denied_name_patterns"` // forbidden plugin name globs
+	MinVersion         string   `toml:"min_version"`          // oldest Cubase version permitted
+	MaxVersion         string   `toml:"max_version"`          // newest Cubase version permitted
+}
+
 // The main configuration structure for the tool.
 type Config struct {
 	PathIgnorePatterns []string `toml:"path_ignore_patterns"` // project path patterns to skip
 	Projects           Projects `toml:"projects"`             // configuration related to projects
 	Plugins            Plugins  `toml:"plugins"`              // configuration related to plugins
+	Policy             Policy   `toml:"policy"`               // plugin policy to enforce
 }
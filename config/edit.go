@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	ErrReadConfigFile  = errors.New("unable to read the config file")
+	ErrParseConfigFile = errors.New("unable to parse the config file")
+	ErrEditConfigFile  = errors.New("unable to locate the guid_ignores setting in the config file")
+	ErrWriteConfigFile = errors.New("unable to write the config file")
+)
+
+var guidIgnoresKeyRegexp = regexp.MustCompile(`^\s*guid_ignores\s*=\s*`)
+
+// AddGUIDIgnore adds a plugin GUID to the guid_ignores setting of the config file at the path
+// provided.  The file is edited in place so that existing comments and formatting are preserved,
+// and it is created if it doesn't exist yet.  Nothing is changed when the GUID is already ignored.
+func AddGUIDIgnore(path string, guid string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %w", ErrReadConfigFile, err)
+	}
+
+	contents := string(data)
+
+	var cfg Config
+	if _, err := toml.Decode(contents, &cfg); err != nil {
+		return fmt.Errorf("%w: %w", ErrParseConfigFile, err)
+	}
+
+	if slices.Contains(cfg.Plugins.GUIDIgnores, guid) {
+		return nil
+	}
+
+	edited, err := insertGUIDIgnore(contents, guid)
+	if err != nil {
+		return err
+	}
+
+	// The edited contents are parsed again to be certain that the file hasn't been corrupted.
+	var editedCfg Config
+	if _, err := toml.Decode(edited, &editedCfg); err != nil ||
+		!slices.Contains(editedCfg.Plugins.GUIDIgnores, guid) {
+		return ErrEditConfigFile
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteConfigFile, err)
+	}
+
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		return fmt.Errorf("%w: %w", ErrWriteConfigFile, err)
+	}
+
+	return nil
+}
+
+// insertGUIDIgnore returns the config file contents provided with the GUID added to the
+// guid_ignores array of the plugins table, adding the array or table when they don't exist.
+func insertGUIDIgnore(contents string, guid string) (string, error) {
+	quoted := fmt.Sprintf("%q", guid)
+
+	table := ""
+	pluginsTableEnd := -1
+	offset := 0
+
+	for _, line := range strings.SplitAfter(contents, "\n") {
+		lineStart := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			table = strings.TrimSpace(strings.Trim(strings.SplitN(trimmed, "#", 2)[0], "[] \t"))
+			if table == "plugins" {
+				pluginsTableEnd = offset
+			}
+
+			continue
+		}
+
+		if table != "plugins" {
+			continue
+		}
+
+		match := guidIgnoresKeyRegexp.FindStringIndex(line)
+		if match == nil {
+			continue
+		}
+
+		return insertArrayValue(contents, lineStart+match[1], quoted)
+	}
+
+	array := "guid_ignores = [\n    " + quoted + ",\n]\n"
+
+	if pluginsTableEnd != -1 {
+		return contents[:pluginsTableEnd] + array + contents[pluginsTableEnd:], nil
+	}
+
+	if contents != "" && !strings.HasSuffix(contents, "\n") {
+		contents += "\n"
+	}
+
+	if contents != "" {
+		contents += "\n"
+	}
+
+	return contents + "[plugins]\n" + array, nil
+}
+
+// insertArrayValue returns the contents provided with the value appended to the array which begins
+// at the start offset.  Multi-line arrays receive the value on its own line and single-line arrays
+// receive the value before their closing bracket.
+func insertArrayValue(contents string, start int, value string) (string, error) {
+	if start >= len(contents) || contents[start] != '[' {
+		return "", ErrEditConfigFile
+	}
+
+	// The offset of the last character in the array which isn't whitespace or part of a comment.
+	last := start
+	var quote byte
+
+	for i := start + 1; i < len(contents); i++ {
+		c := contents[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+			last = i
+		case c == '"' || c == '\'':
+			quote = c
+			last = i
+		case c == '#':
+			for i < len(contents) && contents[i] != '\n' {
+				i++
+			}
+		case c == ']':
+			lineStart := strings.LastIndex(contents[:i], "\n") + 1
+			multiLine := strings.TrimSpace(contents[lineStart:i]) == "" && lineStart > start
+
+			var separator string
+			if contents[last] != '[' && contents[last] != ',' {
+				separator = ","
+			}
+
+			if multiLine {
+				return contents[:last+1] + separator + contents[last+1:lineStart] +
+					"    " + value + ",\n" + contents[lineStart:], nil
+			}
+
+			if separator != "" || contents[last] == ',' {
+				separator += " "
+			}
+
+			return contents[:last+1] + separator + value + contents[last+1:], nil
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			last = i
+		}
+	}
+
+	return "", ErrEditConfigFile
+}
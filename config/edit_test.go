@@ -0,0 +1,114 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+)
+
+const testGUID = "565354416D62726F6D6E697370686572"
+
+func TestAddGUIDIgnore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		contents string
+		expected string
+	}{
+		{
+			name: "multi-line array",
+			contents: `[plugins]
+# Plugins to ignore.
+guid_ignores = [
+    "D1B42E80F1124DFEAFEDE2480EFB4298", # Sampler Track
+    "297BA567D83144E1AE921DEF07B41156" # EQ
+]
+
+name_ignores = []
+`,
+			expected: `[plugins]
+# Plugins to ignore.
+guid_ignores = [
+    "D1B42E80F1124DFEAFEDE2480EFB4298", # Sampler Track
+    "297BA567D83144E1AE921DEF07B41156", # EQ
+    "565354416D62726F6D6E697370686572",
+]
+
+name_ignores = []
+`,
+		},
+		{
+			name:     "empty single-line array",
+			contents: "[plugins]\nguid_ignores = []\n",
+			expected: "[plugins]\nguid_ignores = [\"565354416D62726F6D6E697370686572\"]\n",
+		},
+		{
+			name: "single-line array",
+			contents: "[plugins]\n" +
+				"guid_ignores = [\"D1B42E80F1124DFEAFEDE2480EFB4298\"] # Ignored\n",
+			expected: "[plugins]\nguid_ignores = [\"D1B42E80F1124DFEAFEDE2480EFB4298\", " +
+				"\"565354416D62726F6D6E697370686572\"] # Ignored\n",
+		},
+		{
+			name:     "missing array",
+			contents: "[plugins]\nname_ignores = []\n",
+			expected: "[plugins]\nguid_ignores = [\n" +
+				"    \"565354416D62726F6D6E697370686572\",\n]\nname_ignores = []\n",
+		},
+		{
+			name:     "missing table",
+			contents: "[projects]\nreport_32_bit = false\n",
+			expected: "[projects]\nreport_32_bit = false\n\n[plugins]\nguid_ignores = [\n" +
+				"    \"565354416D62726F6D6E697370686572\",\n]\n",
+		},
+		{
+			name:     "already ignored",
+			contents: "[plugins]\nguid_ignores = [\"565354416D62726F6D6E697370686572\"]\n",
+			expected: "[plugins]\nguid_ignores = [\"565354416D62726F6D6E697370686572\"]\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0o644))
+
+			require.NoError(t, config.AddGUIDIgnore(path, testGUID))
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, string(data))
+		})
+	}
+}
+
+func TestAddGUIDIgnoreMissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".config", "config.toml")
+	require.NoError(t, config.AddGUIDIgnore(path, testGUID))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"[plugins]\nguid_ignores = [\n    \"565354416D62726F6D6E697370686572\",\n]\n",
+		string(data),
+	)
+}
+
+func TestAddGUIDIgnoreInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[plugins"), 0o644))
+
+	require.ErrorIs(t, config.AddGUIDIgnore(path, testGUID), config.ErrParseConfigFile)
+}
@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+// A plugin whose name differs between two projects while its GUID remains the same.
+type Rename struct {
+	GUID    string        `json:"guid"`     // globally unique identifier for the plugin
+	OldName string        `json:"old_name"` // name of the plugin in the old project
+	NewName string        `json:"new_name"` // name of the plugin in the new project
+	Format  parser.Format `json:"format"`   // format of the plugin
+}
+
+// A metadata field whose value differs between two projects.
+type MetadataChange struct {
+	Field string `json:"field"` // name of the field as used in JSON output (e.g. "version")
+	Old   string `json:"old"`   // value of the field in the old project
+	New   string `json:"new"`   // value of the field in the new project
+}
+
+// The differences between the plugins and metadata of two projects.  Plugins are compared by
+// GUID so that a plugin which was renamed (e.g. by its vendor in a later release) is reported as
+// renamed rather than removed and added.
+type ProjectDiff struct {
+	Metadata  []MetadataChange    `json:"metadata"`  // metadata fields which changed
+	Added     []parser.PluginInfo `json:"added"`     // plugins only used in the new project
+	Removed   []parser.PluginInfo `json:"removed"`   // plugins only used in the old project
+	Renamed   []Rename            `json:"renamed"`   // plugins used in both under different names
+	Unchanged []parser.PluginInfo `json:"unchanged"` // plugins used in both under the same name
+}
+
+// HasChanges determines whether the projects differ in any way.
+func (d *ProjectDiff) HasChanges() bool {
+	return len(d.Metadata) > 0 || len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Renamed) > 0
+}
+
+// Projects compares an old and new revision of a project.  All plugin lists in the diff are
+// sorted by name.
+func Projects(oldProject, newProject *parser.Project) ProjectDiff {
+	var added, removed, unchanged []parser.Plugin
+
+	d := ProjectDiff{
+		Metadata: Metadata(oldProject.Metadata, newProject.Metadata),
+		Renamed:  []Rename{},
+	}
+
+	oldPlugins := pluginsByGUID(oldProject.Plugins)
+	newPlugins := pluginsByGUID(newProject.Plugins)
+
+	for guid, oldPlugin := range oldPlugins {
+		newPlugin, ok := newPlugins[guid]
+		switch {
+		case !ok:
+			removed = append(removed, oldPlugin)
+		case newPlugin.Name != oldPlugin.Name:
+			d.Renamed = append(d.Renamed, Rename{
+				GUID:    guid,
+				OldName: oldPlugin.Name,
+				NewName: newPlugin.Name,
+				Format:  newPlugin.Format(),
+			})
+		default:
+			unchanged = append(unchanged, oldPlugin)
+		}
+	}
+
+	for guid, newPlugin := range newPlugins {
+		if _, ok := oldPlugins[guid]; !ok {
+			added = append(added, newPlugin)
+		}
+	}
+
+	d.Added = sortedInfo(added)
+	d.Removed = sortedInfo(removed)
+	d.Unchanged = sortedInfo(unchanged)
+	slices.SortFunc(d.Renamed, func(a, b Rename) int {
+		return cmp.Or(cmp.Compare(a.OldName, b.OldName), cmp.Compare(a.GUID, b.GUID))
+	})
+
+	return d
+}
+
+// Metadata returns the fields which differ between the old and new metadata provided.
+func Metadata(oldMetadata, newMetadata parser.Metadata) []MetadataChange {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"application", oldMetadata.Application, newMetadata.Application},
+		{"version", oldMetadata.Version, newMetadata.Version},
+		{"release_date", oldMetadata.ReleaseDate, newMetadata.ReleaseDate},
+		{"architecture", oldMetadata.Architecture, newMetadata.Architecture},
+	}
+
+	changes := []MetadataChange{}
+	for _, field := range fields {
+		if field.before != field.after {
+			changes = append(
+				changes, MetadataChange{Field: field.name, Old: field.before, New: field.after},
+			)
+		}
+	}
+
+	return changes
+}
+
+// sortedInfo sorts the plugins provided by name and returns them along with their formats.
+func sortedInfo(plugins []parser.Plugin) []parser.PluginInfo {
+	parser.SortPluginsByName(plugins)
+
+	infos := make([]parser.PluginInfo, 0, len(plugins))
+	for _, plugin := range plugins {
+		infos = append(infos, plugin.Info())
+	}
+
+	return infos
+}
+
+// pluginsByGUID indexes the plugins provided by GUID.  When a project contains several names for
+// the same GUID, the name which sorts first is used so that comparisons are deterministic.
+func pluginsByGUID(plugins []parser.Plugin) map[string]parser.Plugin {
+	byGUID := make(map[string]parser.Plugin, len(plugins))
+	for _, plugin := range plugins {
+		if existing, ok := byGUID[plugin.GUID]; !ok || plugin.Name < existing.Name {
+			byGUID[plugin.GUID] = plugin
+		}
+	}
+
+	return byGUID
+}
@@ -0,0 +1,102 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/diff"
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestProjects(t *testing.T) {
+	t.Parallel()
+
+	oldProject := &parser.Project{
+		Metadata: parser.Metadata{
+			Application:  "Cubase",
+			Version:      "11.0.41",
+			ReleaseDate:  "Sep 27 2021",
+			Architecture: "WIN64",
+		},
+		Plugins: []parser.Plugin{
+			{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+			{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"},
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+		},
+	}
+	newProject := &parser.Project{
+		Metadata: parser.Metadata{
+			Application:  "Cubase",
+			Version:      "13.0.10",
+			ReleaseDate:  "Oct 10 2023",
+			Architecture: "WIN64",
+		},
+		Plugins: []parser.Plugin{
+			{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive 2"},
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+			{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"},
+		},
+	}
+
+	d := diff.Projects(oldProject, newProject)
+
+	require.True(t, d.HasChanges())
+	require.Equal(t, []diff.MetadataChange{
+		{Field: "version", Old: "11.0.41", New: "13.0.10"},
+		{Field: "release_date", Old: "Sep 27 2021", New: "Oct 10 2023"},
+	}, d.Metadata)
+	require.Equal(
+		t,
+		[]parser.PluginInfo{
+			{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ", Format: parser.FormatVST3},
+		},
+		d.Added,
+	)
+	require.Equal(
+		t,
+		[]parser.PluginInfo{
+			{
+				GUID:   "565354416D62726F6D6E697370686572",
+				Name:   "Omnisphere",
+				Format: parser.FormatVST2,
+			},
+		},
+		d.Removed,
+	)
+	require.Equal(t, []diff.Rename{
+		{
+			GUID:    "D39D5B69D6AF42FA1234567868495645",
+			OldName: "Hive",
+			NewName: "Hive 2",
+			Format:  parser.FormatVST3,
+		},
+	}, d.Renamed)
+	require.Equal(
+		t,
+		[]parser.PluginInfo{
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant", Format: parser.FormatVST3},
+		},
+		d.Unchanged,
+	)
+}
+
+func TestProjectsIdentical(t *testing.T) {
+	t.Parallel()
+
+	project := &parser.Project{
+		Metadata: parser.Metadata{Application: "Cubase", Version: "13.0.10"},
+		Plugins: []parser.Plugin{
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+		},
+	}
+
+	d := diff.Projects(project, project)
+
+	require.False(t, d.HasChanges())
+	require.Empty(t, d.Metadata)
+	require.Empty(t, d.Added)
+	require.Empty(t, d.Removed)
+	require.Empty(t, d.Renamed)
+	require.Len(t, d.Unchanged, 1)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/fgimian/cubase-project-plugins/cmd"
@@ -8,6 +9,17 @@
 
 func main() {
 	if err := cmd.Execute(); err != nil {
+		// Policy violations take priority over projects which failed to parse so that CI can
+		// distinguish them when both occur.
+		if errors.Is(err, cmd.ErrPolicyViolations) {
+			os.Exit(3)
+		}
+
+		// A distinct exit code is used when scanning completed but some projects failed to parse.
+		if errors.Is(err, cmd.ErrProjectsFailed) {
+			os.Exit(2)
+		}
+
 		os.Exit(1)
 	}
 }
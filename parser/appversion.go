@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAppVersion   = errors.New("the Cubase version is invalid")
+	ErrInvalidVersionRange = errors.New("the minimum Cubase version is greater than the maximum")
+)
+
+// Matches the first dotted sequence of numbers in a version string, which skips any prefix such
+// as "SX" (e.g. "SX3" or "SX 3.1.1") and any suffix such as a build number.
+var appVersionRegexp = regexp.MustCompile(`\d+(?:\.\d+)*`)
+
+// A Cubase version (e.g. "13.0.10") split into its numeric components so that versions may be
+// compared.
+type AppVersion struct {
+	Components []int // numeric components in order of significance
+}
+
+// ParseAppVersion parses a version string such as those found in project metadata.
+func ParseAppVersion(version string) (AppVersion, error) {
+	match := appVersionRegexp.FindString(version)
+	if match == "" {
+		return AppVersion{}, fmt.Errorf("%w: %q", ErrInvalidAppVersion, version)
+	}
+
+	var v AppVersion
+	for _, component := range strings.Split(match, ".") {
+		number, err := strconv.Atoi(component)
+		if err != nil {
+			return AppVersion{}, fmt.Errorf("%w: %q", ErrInvalidAppVersion, version)
+		}
+
+		v.Components = append(v.Components, number)
+	}
+
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether the version is older than, the same as or
+// newer than the other version.  Missing components are treated as zero so "13" and "13.0.0" are
+// the same version.
+func (v AppVersion) Compare(other AppVersion) int {
+	for i := range max(len(v.Components), len(other.Components)) {
+		if result := cmp.Compare(v.component(i), other.component(i)); result != 0 {
+			return result
+		}
+	}
+
+	return 0
+}
+
+// String returns the version in dotted form.
+func (v AppVersion) String() string {
+	components := make([]string, 0, len(v.Components))
+	for _, component := range v.Components {
+		components = append(components, strconv.Itoa(component))
+	}
+
+	return strings.Join(components, ".")
+}
+
+func (v AppVersion) component(i int) int {
+	if i < len(v.Components) {
+		return v.Components[i]
+	}
+
+	return 0
+}
+
+// truncate returns the version with only the number of components provided.
+func (v AppVersion) truncate(length int) AppVersion {
+	if len(v.Components) <= length {
+		return v
+	}
+
+	return AppVersion{Components: v.Components[:length]}
+}
+
+// An inclusive range of Cubase versions.  Each limit only considers as many components as it
+// specifies, so a maximum of "12" includes every 12.x release while a maximum of "12.0.20"
+// excludes 12.0.30.
+type VersionRange struct {
+	Min *AppVersion // oldest version in the range or nil when unbounded
+	Max *AppVersion // newest version in the range or nil when unbounded
+}
+
+// ParseVersionRange parses the minimum and maximum versions provided, either of which may be empty
+// to leave that end of the range unbounded.
+func ParseVersionRange(minVersion, maxVersion string) (VersionRange, error) {
+	var r VersionRange
+
+	if minVersion != "" {
+		v, err := ParseAppVersion(minVersion)
+		if err != nil {
+			return VersionRange{}, err
+		}
+
+		r.Min = &v
+	}
+
+	if maxVersion != "" {
+		v, err := ParseAppVersion(maxVersion)
+		if err != nil {
+			return VersionRange{}, err
+		}
+
+		r.Max = &v
+	}
+
+	// The minimum is only compared using as many components as the maximum specifies, as a
+	// maximum of "12" includes every 12.x release and so a minimum of "12.5" is valid.
+	if r.Min != nil && r.Max != nil && r.Min.truncate(len(r.Max.Components)).Compare(*r.Max) > 0 {
+		return VersionRange{}, fmt.Errorf(
+			"%w: %s > %s", ErrInvalidVersionRange, minVersion, maxVersion,
+		)
+	}
+
+	return r, nil
+}
+
+// IsBounded determines whether the range has a minimum or maximum version.
+func (r VersionRange) IsBounded() bool {
+	return r.Min != nil || r.Max != nil
+}
+
+// Contains determines whether the version provided is within the range.
+func (r VersionRange) Contains(v AppVersion) bool {
+	if r.Min != nil && v.truncate(len(r.Min.Components)).Compare(*r.Min) < 0 {
+		return false
+	}
+
+	if r.Max != nil && v.truncate(len(r.Max.Components)).Compare(*r.Max) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// String describes the range for use in messages (e.g. ">= 9.5, <= 12").
+func (r VersionRange) String() string {
+	var limits []string
+
+	if r.Min != nil {
+		limits = append(limits, ">= "+r.Min.String())
+	}
+
+	if r.Max != nil {
+		limits = append(limits, "<= "+r.Max.String())
+	}
+
+	return strings.Join(limits, ", ")
+}
@@ -0,0 +1,107 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestParseAppVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		version  string
+		expected []int
+	}{
+		{version: "4.5.2", expected: []int{4, 5, 2}},
+		{version: "13.0.10", expected: []int{13, 0, 10}},
+		{version: "3.1.1", expected: []int{3, 1, 1}},
+		{version: "SX3", expected: []int{3}},
+		{version: "SX 3.1.1", expected: []int{3, 1, 1}},
+		{version: "12.0.70 Build 437", expected: []int{12, 0, 70}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := parser.ParseAppVersion(tc.version)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.Components)
+		})
+	}
+
+	_, err := parser.ParseAppVersion("Unknown")
+	require.ErrorIs(t, err, parser.ErrInvalidAppVersion)
+}
+
+func TestAppVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "4.5.2", b: "13.0.10", expected: -1},
+		{a: "13.0.10", b: "9.5.41", expected: 1},
+		{a: "SX3", b: "4", expected: -1},
+		{a: "13", b: "13.0.0", expected: 0},
+		{a: "8.5.30", b: "8.5.3", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		a, err := parser.ParseAppVersion(tc.a)
+		require.NoError(t, err)
+
+		b, err := parser.ParseAppVersion(tc.b)
+		require.NoError(t, err)
+
+		require.Equal(t, tc.expected, a.Compare(b), "%s compared with %s", tc.a, tc.b)
+	}
+}
+
+func TestVersionRange(t *testing.T) {
+	t.Parallel()
+
+	r, err := parser.ParseVersionRange("9.5", "12")
+	require.NoError(t, err)
+	require.True(t, r.IsBounded())
+	require.Equal(t, ">= 9.5, <= 12", r.String())
+
+	testCases := map[string]bool{
+		"3.1.1":   false,
+		"9.0.40":  false,
+		"9.5.0":   true,
+		"9.5.41":  true,
+		"12.0.70": true,
+		"13.0.10": false,
+	}
+
+	for version, expected := range testCases {
+		v, err := parser.ParseAppVersion(version)
+		require.NoError(t, err)
+		require.Equal(t, expected, r.Contains(v), version)
+	}
+
+	unbounded, err := parser.ParseVersionRange("", "")
+	require.NoError(t, err)
+	require.False(t, unbounded.IsBounded())
+
+	partial, err := parser.ParseVersionRange("12.5", "12")
+	require.NoError(t, err)
+
+	v, err := parser.ParseAppVersion("12.5.10")
+	require.NoError(t, err)
+	require.True(t, partial.Contains(v))
+
+	_, err = parser.ParseVersionRange("13", "12")
+	require.ErrorIs(t, err, parser.ErrInvalidVersionRange)
+
+	_, err = parser.ParseVersionRange("12.0.30", "12.0.20")
+	require.ErrorIs(t, err, parser.ErrInvalidVersionRange)
+
+	_, err = parser.ParseVersionRange("latest", "")
+	require.ErrorIs(t, err, parser.ErrInvalidAppVersion)
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrUnknownFormat = errors.New("the plugin format requested is not supported")
+
+// The format of a plugin, which is detected from its GUID.
+type Format string
+
+// The plugin formats which may be detected.  Cubase hosts VST2 plugins using a GUID made up of
+// "VST", the four character unique ID of the plugin and the start of its name in lower case, so
+// any other GUID belongs to a native VST3 plugin.
+const (
+	FormatVST2 Format = "vst2"
+	FormatVST3 Format = "vst3"
+)
+
+// Formats lists every plugin format which may be detected.
+var Formats = []Format{FormatVST2, FormatVST3}
+
+// The prefix of the GUIDs of VST2 plugins.
+const vst2GUIDPrefix = "VST"
+
+// The length of the unique ID of VST2 plugins.
+const vst2UniqueIDLength = 4
+
+// ParseFormat parses the name of a plugin format, ignoring case.
+func ParseFormat(format string) (Format, error) {
+	if !slices.Contains(Formats, Format(strings.ToLower(format))) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+
+	return Format(strings.ToLower(format)), nil
+}
+
+// The details embedded in the GUID of a VST2 plugin.
+type VST2ID struct {
+	UniqueID     string // four character unique ID of the plugin (e.g. "syl1" for Sylenth1)
+	NameFragment string // lower case start of the plugin name (e.g. "sylenth1")
+}
+
+// DecodeVST2GUID decodes the unique ID and name fragment embedded in the GUID of a VST2 plugin
+// (e.g. "5653544D6574336D6574616C697A6572" which is "VST", "Met3" and "metalizer").  The last
+// result is false when the GUID doesn't belong to a VST2 plugin.
+func DecodeVST2GUID(guid string) (VST2ID, bool) {
+	data, err := hex.DecodeString(guid)
+	if err != nil || len(data) != 16 || !bytes.HasPrefix(data, []byte(vst2GUIDPrefix)) {
+		return VST2ID{}, false
+	}
+
+	uniqueID := data[len(vst2GUIDPrefix) : len(vst2GUIDPrefix)+vst2UniqueIDLength]
+	if !isPrintable(uniqueID) {
+		return VST2ID{}, false
+	}
+
+	// The name fragment is padded with nul bytes when the name is shorter than the space
+	// available.
+	nameFragment := data[len(vst2GUIDPrefix)+vst2UniqueIDLength:]
+	if nulIndex := bytes.IndexByte(nameFragment, 0); nulIndex != -1 {
+		if len(bytes.TrimLeft(nameFragment[nulIndex:], "\x00")) > 0 {
+			return VST2ID{}, false
+		}
+
+		nameFragment = nameFragment[:nulIndex]
+	}
+
+	if !isPrintable(nameFragment) {
+		return VST2ID{}, false
+	}
+
+	return VST2ID{UniqueID: string(uniqueID), NameFragment: string(nameFragment)}, true
+}
+
+// Format returns the format of the plugin, which is detected from its GUID.
+func (p Plugin) Format() Format {
+	if _, ok := DecodeVST2GUID(p.GUID); ok {
+		return FormatVST2
+	}
+
+	return FormatVST3
+}
+
+// A plugin along with its format, which is used where the format is included in the output.
+type PluginInfo struct {
+	GUID   string `json:"guid"`   // globally unique identifier for the plugin
+	Name   string `json:"name"`   // name of the plugin
+	Format Format `json:"format"` // format of the plugin
+}
+
+// Info returns the plugin along with its format.
+func (p Plugin) Info() PluginInfo {
+	return PluginInfo{GUID: p.GUID, Name: p.Name, Format: p.Format()}
+}
+
+// Plugin returns the plugin described without its format.
+func (i PluginInfo) Plugin() Plugin {
+	return Plugin{GUID: i.GUID, Name: i.Name}
+}
+
+// isPrintable determines whether every byte provided is a printable ASCII character.
+func isPrintable(data []byte) bool {
+	for _, b := range data {
+		if b < 0x20 || b > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range parser.Formats {
+		parsed, err := parser.ParseFormat(string(format))
+		require.NoError(t, err)
+		require.Equal(t, format, parsed)
+	}
+
+	parsed, err := parser.ParseFormat("VST2")
+	require.NoError(t, err)
+	require.Equal(t, parser.FormatVST2, parsed)
+
+	_, err = parser.ParseFormat("aax")
+	require.ErrorIs(t, err, parser.ErrUnknownFormat)
+}
+
+func TestDecodeVST2GUID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		guid     string
+		expected parser.VST2ID
+		ok       bool
+	}{
+		{
+			name:     "full name fragment",
+			guid:     "5653544D6574336D6574616C697A6572",
+			expected: parser.VST2ID{UniqueID: "Met3", NameFragment: "metalizer"},
+			ok:       true,
+		},
+		{
+			name:     "padded name fragment",
+			guid:     "56535473796C3173796C656E74683100",
+			expected: parser.VST2ID{UniqueID: "syl1", NameFragment: "sylenth1"},
+			ok:       true,
+		},
+		{
+			name:     "short name fragment",
+			guid:     "56535455564852757632326872000000",
+			expected: parser.VST2ID{UniqueID: "UVHR", NameFragment: "uv22hr"},
+			ok:       true,
+		},
+		{
+			name: "native VST3 plugin",
+			guid: "D39D5B69D6AF42FA1234567868495645",
+		},
+		{
+			name: "data after padding",
+			guid: "56535473796C3173796C656E00683100",
+		},
+		{
+			name: "invalid hex",
+			guid: "not a guid",
+		},
+		{
+			name: "too short",
+			guid: "5653544D6574336D",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id, ok := parser.DecodeVST2GUID(tc.guid)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestPluginFormat(t *testing.T) {
+	t.Parallel()
+
+	vst2 := parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+	require.Equal(t, parser.FormatVST2, vst2.Format())
+
+	vst3 := parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+	require.Equal(t, parser.FormatVST3, vst3.Format())
+}
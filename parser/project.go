@@ -1,21 +1,73 @@
 package parser
 
+import "slices"
+
 // Contains information about the Cubase version used to create the project.
 type Metadata struct {
-	Application  string // application name (this is always "Cubase")
-	Version      string // version of Cubase used to create the project
-	ReleaseDate  string // release date of the Cubase version used
-	Architecture string // system architecture used to create the project
+	Application  string `json:"application"`  // application name (this is always "Cubase")
+	Version      string `json:"version"`      // version of Cubase used to create the project
+	ReleaseDate  string `json:"release_date"` // release date of the Cubase version used
+	Architecture string `json:"architecture"` // system architecture used to create the project
 }
 
 // Represents a plugin within a Cubase project.
 type Plugin struct {
-	GUID string // globally unique identifier for the plugin
-	Name string // name of the plugin
+	GUID string `json:"guid"` // globally unique identifier for the plugin
+	Name string `json:"name"` // name of the plugin
+}
+
+// An individual instance of a plugin within a Cubase project.  The track title is only set in
+// Cubase 8.x and above when an instrument track has been renamed, as the plugin name is otherwise
+// used as the track title.
+type Occurrence struct {
+	Plugin
+	Offset     int    `json:"offset"`                // byte offset of the plugin in the project
+	TrackTitle string `json:"track_title,omitempty"` // title of a renamed instrument track
+	Role       Role   `json:"role,omitempty"`        // role inferred for the plugin if known
 }
 
 // Captures the Cubase version and all plugins used for a Cubase project.
 type Project struct {
-	Metadata Metadata // metadata describing the Cubase version used to create the project
-	Plugins  []Plugin // plugins used in the project
+	Metadata    Metadata     `json:"metadata"`    // metadata describing the Cubase version used
+	Plugins     []Plugin     `json:"plugins"`     // unique plugins in order of appearance
+	Occurrences []Occurrence `json:"occurrences"` // every plugin instance in order of offset
+}
+
+// InstanceCounts returns the number of instances of each plugin used in the project.
+func (p *Project) InstanceCounts() map[Plugin]int {
+	counts := make(map[Plugin]int, len(p.Plugins))
+	for _, occurrence := range p.Occurrences {
+		counts[occurrence.Plugin]++
+	}
+
+	return counts
+}
+
+// TrackTitles returns the unique track titles of each plugin used in the project in the order
+// they appear.  Plugins without any track titles aren't included.
+func (p *Project) TrackTitles() map[Plugin][]string {
+	titles := make(map[Plugin][]string)
+	for _, occurrence := range p.Occurrences {
+		if occurrence.TrackTitle != "" &&
+			!slices.Contains(titles[occurrence.Plugin], occurrence.TrackTitle) {
+			titles[occurrence.Plugin] = append(titles[occurrence.Plugin], occurrence.TrackTitle)
+		}
+	}
+
+	return titles
+}
+
+// Roles returns the unique roles of each plugin used in the project in the order of the roles
+// listed in Roles.  Plugins without any known roles aren't included.
+func (p *Project) Roles() map[Plugin][]Role {
+	roles := make(map[Plugin][]Role)
+	for _, role := range Roles {
+		for _, occurrence := range p.Occurrences {
+			if occurrence.Role == role && !slices.Contains(roles[occurrence.Plugin], role) {
+				roles[occurrence.Plugin] = append(roles[occurrence.Plugin], role)
+			}
+		}
+	}
+
+	return roles
 }
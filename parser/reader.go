@@ -39,11 +39,16 @@ func NewReader(projectBytes []byte) Reader {
 }
 
 // GetProjectDetails obtains all project details including Cubase version and plugins used and
-// returns an instance of Project containing project details.
+// returns an instance of Project containing project details.  Every instance of each plugin is
+// also recorded as an occurrence along with its byte offset in the project.  Plugins are returned
+// in the order they first appear in the project and the role of each occurrence is inferred from
+// the structure of the project surrounding it.
 func (r *Reader) GetProjectDetails() (*Project, error) {
 	var metadata *Metadata
 
 	uniquePlugins := make(map[Plugin]Nothing)
+	plugins := []Plugin{}
+	occurrences := []Occurrence{}
 
 	index := 0
 	for index < len(r.projectBytes) {
@@ -70,13 +75,18 @@ func (r *Reader) GetProjectDetails() (*Project, error) {
 		}
 
 		// Check whether the next set of bytes relate to a plugin.
-		foundPlugin, updatedIndex, err := r.searchPlugin(index)
+		foundOccurrence, updatedIndex, err := r.searchPlugin(index)
 		if err != nil {
 			return nil, fmt.Errorf("the project is corrupted: %w", err)
 		}
 
-		if foundPlugin != nil {
-			uniquePlugins[*foundPlugin] = Nothing{}
+		if foundOccurrence != nil {
+			if _, found := uniquePlugins[foundOccurrence.Plugin]; !found {
+				uniquePlugins[foundOccurrence.Plugin] = Nothing{}
+				plugins = append(plugins, foundOccurrence.Plugin)
+			}
+
+			occurrences = append(occurrences, *foundOccurrence)
 			index = updatedIndex
 
 			continue
@@ -89,12 +99,18 @@ func (r *Reader) GetProjectDetails() (*Project, error) {
 		return nil, ErrCorruptProject
 	}
 
-	plugins := make([]Plugin, 0, len(uniquePlugins))
-	for plugin := range uniquePlugins {
-		plugins = append(plugins, plugin)
+	r.assignRoles(occurrences)
+
+	// Cubase also stores the former name of a plugin which has been renamed in a later release
+	// (e.g. UV22HR which became Lin Dither) the same way as a track title, so only the titles of
+	// instruments are kept.
+	for i := range occurrences {
+		if occurrences[i].Role != RoleInstrument {
+			occurrences[i].TrackTitle = ""
+		}
 	}
 
-	return &Project{Metadata: *metadata, Plugins: plugins}, nil
+	return &Project{Metadata: *metadata, Plugins: plugins, Occurrences: occurrences}, nil
 }
 
 func (r *Reader) searchMetadata(index int) (*Metadata, int, error) {
@@ -146,7 +162,9 @@ func (r *Reader) searchMetadata(index int) (*Metadata, int, error) {
 	return &metadata, index, nil
 }
 
-func (r *Reader) searchPlugin(index int) (*Plugin, int, error) {
+func (r *Reader) searchPlugin(index int) (*Occurrence, int, error) {
+	offset := index
+
 	uidTerm := r.getBytes(index, len(PluginUIDSearchTerm))
 	if uidTerm == nil || string(uidTerm) != PluginUIDSearchTerm {
 		return nil, 0, nil
@@ -182,8 +200,11 @@ func (r *Reader) searchPlugin(index int) (*Plugin, int, error) {
 
 	// In Cubase 8.x and above, in cases where an instrument track has been renamed using
 	// Shift+Enter, the name retrieved above will be the track title and the name of the plugin
-	// will follow under the key "Original Plugin Name".
+	// will follow under the key "Original Plugin Name".  Track titles of plugins which aren't
+	// instruments are discarded once roles have been inferred.
+	trackTitle := ""
 	if key == "Original Plugin Name" {
+		trackTitle = name
 		index += readBytes + 5
 
 		name, readBytes, err = r.getToken(index)
@@ -194,9 +215,13 @@ func (r *Reader) searchPlugin(index int) (*Plugin, int, error) {
 		index += readBytes
 	}
 
-	plugin := Plugin{GUID: guid, Name: name}
+	occurrence := Occurrence{
+		Plugin:     Plugin{GUID: guid, Name: name},
+		Offset:     offset,
+		TrackTitle: trackTitle,
+	}
 
-	return &plugin, index, nil
+	return &occurrence, index, nil
 }
 
 func (r *Reader) getBytes(index, length int) []byte {
@@ -179,12 +179,166 @@ func TestGetProjectDetails(t *testing.T) {
 					},
 					Plugins: expectedPlugins,
 				},
-				*project,
+				parser.Project{Metadata: project.Metadata, Plugins: project.Plugins},
 			)
+
+			counts := project.InstanceCounts()
+			require.Len(t, counts, len(expectedPlugins))
+			for _, plugin := range expectedPlugins {
+				require.Positive(t, counts[plugin], plugin.Name)
+			}
+
+			require.True(t, slices.IsSortedFunc(
+				project.Occurrences,
+				func(a, b parser.Occurrence) int { return cmp.Compare(a.Offset, b.Offset) },
+			))
 		})
 	}
 }
 
+func TestGetProjectDetailsOccurrences(t *testing.T) {
+	t.Parallel()
+
+	projectBytes, err := os.ReadFile(filepath.Join("testdata", "Example Project (Cubase 13).cpr"))
+	require.NoError(t, err)
+
+	reader := parser.NewReader(projectBytes)
+	project, err := reader.GetProjectDetails()
+	require.NoError(t, err)
+
+	require.Len(t, project.Occurrences, 154)
+	require.Equal(
+		t,
+		parser.Occurrence{
+			Plugin: parser.Plugin{GUID: "D56B9C6CA4F946018EED73EB83A74B58", Name: "Input Filter"},
+			Offset: 3490,
+			Role:   parser.RoleChannelStrip,
+		},
+		project.Occurrences[0],
+	)
+	require.Equal(
+		t,
+		parser.Occurrence{
+			Plugin: parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+			Offset: 697966,
+			Role:   parser.RoleMaster,
+		},
+		project.Occurrences[123],
+	)
+
+	for _, occurrence := range project.Occurrences {
+		require.Equal(t, "Plugin UID", string(projectBytes[occurrence.Offset:occurrence.Offset+10]))
+	}
+
+	require.Equal(
+		t,
+		map[parser.Plugin]int{
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}:           1,
+			{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"}:                 8,
+			{GUID: "44E1149EDB3E4387BDD827FEA3A39EE7", Name: "Standard Panner"}:    126,
+			{GUID: "565354414152626172747361636F7573", Name: "ArtsAcousticReverb"}: 1,
+			{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}:         1,
+			{GUID: "56535444475443747261636B636F6D70", Name: "TrackComp"}:          2,
+			{GUID: "56535455564852757632326872000000", Name: "Lin Dither"}:         1,
+			{GUID: "56535473796C3173796C656E74683100", Name: "Sylenth1"}:           1,
+			{GUID: "77BBA7CA90F14C9BB298BA9010D6DD78", Name: "StereoEnhancer"}:     2,
+			{GUID: "946051208E29496E804F64A825C8A047", Name: "StudioEQ"}:           2,
+			{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}:               1,
+			{GUID: "D56B9C6CA4F946018EED73EB83A74B58", Name: "Input Filter"}:       8,
+		},
+		project.InstanceCounts(),
+	)
+}
+
+func TestGetProjectDetailsTrackTitles(t *testing.T) {
+	t.Parallel()
+
+	projectBytes, err := os.ReadFile(filepath.Join("testdata", "Example Project (Cubase 13).cpr"))
+	require.NoError(t, err)
+
+	reader := parser.NewReader(projectBytes)
+	project, err := reader.GetProjectDetails()
+	require.NoError(t, err)
+
+	omnisphere := parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+
+	require.Equal(
+		t,
+		parser.Occurrence{
+			Plugin:     omnisphere,
+			Offset:     167540,
+			TrackTitle: "Solo Vocalist",
+			Role:       parser.RoleInstrument,
+		},
+		project.Occurrences[71],
+	)
+	require.Equal(
+		t,
+		map[parser.Plugin][]string{
+			omnisphere: {"Solo Vocalist"},
+		},
+		project.TrackTitles(),
+	)
+}
+
+func TestGetProjectDetailsRenamedPlugin(t *testing.T) {
+	t.Parallel()
+
+	projectBytes, err := os.ReadFile(filepath.Join("testdata", "Example Project (Cubase 13).cpr"))
+	require.NoError(t, err)
+
+	reader := parser.NewReader(projectBytes)
+	project, err := reader.GetProjectDetails()
+	require.NoError(t, err)
+
+	// Lin Dither was previously named UV22HR, which Cubase stores in the same way as the title of
+	// a renamed instrument track.
+	ditherPlugin := parser.Plugin{GUID: "56535455564852757632326872000000", Name: "Lin Dither"}
+
+	var ditherOccurrences []parser.Occurrence
+	for _, occurrence := range project.Occurrences {
+		if occurrence.Plugin == ditherPlugin {
+			ditherOccurrences = append(ditherOccurrences, occurrence)
+		}
+	}
+
+	require.NotEmpty(t, ditherOccurrences)
+	for _, occurrence := range ditherOccurrences {
+		require.Empty(t, occurrence.TrackTitle)
+	}
+	require.NotContains(t, project.TrackTitles(), ditherPlugin)
+}
+
+func TestGetProjectDetailsPluginOrder(t *testing.T) {
+	t.Parallel()
+
+	projectBytes, err := os.ReadFile(filepath.Join("testdata", "Example Project (Cubase 13).cpr"))
+	require.NoError(t, err)
+
+	reader := parser.NewReader(projectBytes)
+	project, err := reader.GetProjectDetails()
+	require.NoError(t, err)
+
+	require.Equal(
+		t,
+		[]parser.Plugin{
+			{GUID: "D56B9C6CA4F946018EED73EB83A74B58", Name: "Input Filter"},
+			{GUID: "946051208E29496E804F64A825C8A047", Name: "StudioEQ"},
+			{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"},
+			{GUID: "44E1149EDB3E4387BDD827FEA3A39EE7", Name: "Standard Panner"},
+			{GUID: "56535473796C3173796C656E74683100", Name: "Sylenth1"},
+			{GUID: "56535444475443747261636B636F6D70", Name: "TrackComp"},
+			{GUID: "77BBA7CA90F14C9BB298BA9010D6DD78", Name: "StereoEnhancer"},
+			{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+			{GUID: "565354414152626172747361636F7573", Name: "ArtsAcousticReverb"},
+			{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"},
+			{GUID: "56535455564852757632326872000000", Name: "Lin Dither"},
+			{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"},
+		},
+		project.Plugins,
+	)
+}
+
 func TestGetProjectDetailsSX3(t *testing.T) {
 	t.Parallel()
 
@@ -204,7 +358,8 @@ func TestGetProjectDetailsSX3(t *testing.T) {
 				ReleaseDate:  "Oct 13 2005",
 				Architecture: "Unspecified",
 			},
-			Plugins: []parser.Plugin{},
+			Plugins:     []parser.Plugin{},
+			Occurrences: []parser.Occurrence{},
 		},
 		*project,
 	)
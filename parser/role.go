@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUnknownRole = errors.New("the plugin role requested is not supported")
+
+// The role of a plugin instance within a Cubase project, which describes the slot it occupies.
+type Role string
+
+// The roles which may be inferred for a plugin instance.  Plugins on the master bus are those
+// inserted on the output bus and dither plugins are dithering plugins (e.g. Lin Dither) inserted
+// on any channel.  Channel strip plugins include the built-in input filter, EQ and panner of each
+// channel while send plugins are the panners of sends.
+const (
+	RoleInstrument   Role = "instrument"
+	RoleInsert       Role = "insert"
+	RoleSend         Role = "send"
+	RoleChannelStrip Role = "channel_strip"
+	RoleMaster       Role = "master"
+	RoleDither       Role = "dither"
+)
+
+// Roles lists every role which may be inferred for a plugin instance.
+var Roles = []Role{
+	RoleInstrument, RoleInsert, RoleSend, RoleChannelStrip, RoleMaster, RoleDither,
+}
+
+// The GUIDs of dithering plugins which are bundled with Cubase.
+var ditherGUIDs = []string{
+	"56535455564852757632326872000000", // UV22HR (renamed to Lin Dither in Cubase 12)
+}
+
+// ParseRole parses the name of a role.
+func ParseRole(role string) (Role, error) {
+	if !slices.Contains(Roles, Role(role)) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownRole, role)
+	}
+
+	return Role(role), nil
+}
+
+// The kinds of markers in a project which reveal the structure surrounding plugins.
+type markerKind int
+
+const (
+	markerSlot        markerKind = iota // a folder of slots where plugins are placed
+	markerSendLevel                     // the output level of a send
+	markerPanner                        // the panner of a channel or send
+	markerMasterStart                   // the start of the output bus channels
+	markerMasterEnd                     // the end of the output bus channels
+)
+
+// The maximum number of bytes between the output level of a send and its panner.  Panners which
+// aren't preceded by the output level of a send belong to the channel itself.
+const sendPannerDistance = 64
+
+// A term found in a project which reveals the structure surrounding the plugins that follow it.
+type roleMarker struct {
+	term string     // term to search for in the project
+	kind markerKind // kind of structure the term reveals
+	role Role       // role of plugins in the slots which follow the term
+}
+
+// The terms which determine the role of the plugins that follow them.  Cubase 4.x to 6.x projects
+// don't contain input filters or channel strips so only the remaining terms are found in them.
+var roleMarkers = []roleMarker{
+	{term: "Synth Slot\000", kind: markerSlot, role: RoleInstrument},
+	{term: "Synth Rack\000", kind: markerSlot, role: RoleInstrument},
+	{term: "InsertFolder\000", kind: markerSlot, role: RoleInsert},
+	{term: "SendFolder\000", kind: markerSlot, role: RoleSend},
+	{term: "directRoutingFolder\000", kind: markerSlot, role: RoleSend},
+	{term: "StripFolder\000", kind: markerSlot, role: RoleChannelStrip},
+	{term: "InputFilter\000", kind: markerSlot, role: RoleChannelStrip},
+	{term: "Output\000", kind: markerSendLevel},
+	{term: "Panner\000", kind: markerPanner},
+	{term: "Default Input\000", kind: markerMasterStart},
+	{term: "Default Output\000", kind: markerMasterEnd},
+}
+
+// A marker found in a project.
+type markerMatch struct {
+	offset int         // byte offset of the marker in the project
+	marker *roleMarker // marker which was found
+}
+
+// assignRoles infers the role of each plugin occurrence provided, which must be in order of
+// offset, from the nearest marker before it.  Occurrences which aren't preceded by a marker are
+// left without a role.
+func (r *Reader) assignRoles(occurrences []Occurrence) {
+	var matches []markerMatch
+	for i := range roleMarkers {
+		marker := &roleMarkers[i]
+		term := []byte(marker.term)
+
+		for offset := 0; ; offset += len(term) {
+			index := bytes.Index(r.projectBytes[offset:], term)
+			if index == -1 {
+				break
+			}
+
+			offset += index
+
+			// Terms are stored as tokens preceded by their length, which distinguishes a term
+			// from the end of a longer token such as "Standard Panner".
+			if offset > 0 && int(r.projectBytes[offset-1]) == len(term) {
+				matches = append(matches, markerMatch{offset: offset, marker: marker})
+			}
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b markerMatch) int {
+		return cmp.Compare(a.offset, b.offset)
+	})
+
+	var (
+		role      Role
+		inMaster  bool
+		sendLevel = -sendPannerDistance - 1
+		next      int
+	)
+
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+
+		for next < len(matches) && matches[next].offset < occurrence.Offset {
+			switch marker := matches[next].marker; marker.kind {
+			case markerSlot:
+				role = marker.role
+			case markerSendLevel:
+				sendLevel = matches[next].offset
+			case markerPanner:
+				if matches[next].offset-sendLevel <= sendPannerDistance {
+					role = RoleSend
+				} else {
+					role = RoleChannelStrip
+				}
+			case markerMasterStart:
+				inMaster = true
+			case markerMasterEnd:
+				inMaster = false
+			}
+
+			next++
+		}
+
+		switch {
+		case role == RoleInsert && slices.Contains(ditherGUIDs, occurrence.GUID):
+			occurrence.Role = RoleDither
+		case role == RoleInsert && inMaster:
+			occurrence.Role = RoleMaster
+		default:
+			occurrence.Role = role
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestParseRole(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range parser.Roles {
+		parsed, err := parser.ParseRole(string(role))
+		require.NoError(t, err)
+		require.Equal(t, role, parsed)
+	}
+
+	_, err := parser.ParseRole("sidechain")
+	require.ErrorIs(t, err, parser.ErrUnknownRole)
+}
+
+func TestGetProjectDetailsRoles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		filename         string
+		ditherPluginName string
+		channels         int
+		sends            int
+		channelStrips    bool
+	}{
+		{
+			name:     "Cubase 4.5 64-bit",
+			filename: "Example Project (Cubase 4.5 64-bit).cpr",
+			channels: 6,
+			sends:    52,
+		},
+		{
+			name:          "Cubase 7 64-bit",
+			filename:      "Example Project (Cubase 7 64-bit).cpr",
+			channels:      6,
+			sends:         52,
+			channelStrips: true,
+		},
+		{
+			name:             "Cubase 13",
+			filename:         "Example Project (Cubase 13).cpr",
+			ditherPluginName: "Lin Dither",
+			channels:         8,
+			sends:            118,
+			channelStrips:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectBytes, err := os.ReadFile(filepath.Join("testdata", tc.filename))
+			require.NoError(t, err)
+
+			reader := parser.NewReader(projectBytes)
+			project, err := reader.GetProjectDetails()
+			require.NoError(t, err)
+
+			ditherPluginName := "UV22HR"
+			if tc.ditherPluginName != "" {
+				ditherPluginName = tc.ditherPluginName
+			}
+
+			panner := parser.Plugin{
+				GUID: "44E1149EDB3E4387BDD827FEA3A39EE7", Name: "Standard Panner",
+			}
+
+			roles := project.Roles()
+			require.Equal(t, []parser.Role{parser.RoleInstrument}, roles[parser.Plugin{
+				GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere",
+			}])
+			require.Equal(t, []parser.Role{parser.RoleInsert}, roles[parser.Plugin{
+				GUID: "56535444475443747261636B636F6D70", Name: "TrackComp",
+			}])
+			require.Equal(t, []parser.Role{parser.RoleMaster}, roles[parser.Plugin{
+				GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant",
+			}])
+			require.Equal(t, []parser.Role{parser.RoleDither}, roles[parser.Plugin{
+				GUID: "56535455564852757632326872000000", Name: ditherPluginName,
+			}])
+			require.Equal(t, []parser.Role{parser.RoleSend, parser.RoleChannelStrip}, roles[panner])
+
+			if tc.channelStrips {
+				require.Equal(t, []parser.Role{parser.RoleChannelStrip}, roles[parser.Plugin{
+					GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ",
+				}])
+			}
+
+			roleCounts := make(map[parser.Role]int)
+			for _, occurrence := range project.Occurrences {
+				require.NotEmpty(t, occurrence.Role, occurrence.Name)
+
+				if occurrence.Plugin == panner {
+					roleCounts[occurrence.Role]++
+				}
+			}
+
+			require.Equal(
+				t,
+				map[parser.Role]int{
+					parser.RoleSend:         tc.sends,
+					parser.RoleChannelStrip: tc.channels,
+				},
+				roleCounts,
+			)
+		})
+	}
+}
+
+// projectToken returns the token provided in the form it is stored in a project, which is the
+// length of the token followed by the token and a nul terminator.
+func projectToken(token string) []byte {
+	return append(append([]byte{byte(len(token) + 1)}, token...), 0)
+}
+
+// projectPlugin returns a plugin in the form it is stored in a project.
+func projectPlugin(plugin parser.Plugin) []byte {
+	var b []byte
+	b = append(b, parser.PluginUIDSearchTerm...)
+	b = append(b, make([]byte, 22)...)
+	b = append(b, projectToken(plugin.GUID)...)
+	b = append(b, make([]byte, 3)...)
+	b = append(b, projectToken("Plugin Name")...)
+	b = append(b, make([]byte, 5)...)
+	b = append(b, projectToken(plugin.Name)...)
+	b = append(b, make([]byte, 3)...)
+	b = append(b, projectToken("Audio Input Count")...)
+
+	return b
+}
+
+func TestGetProjectDetailsRoleMarkerBoundaries(t *testing.T) {
+	t.Parallel()
+
+	panner := parser.Plugin{GUID: "44E1149EDB3E4387BDD827FEA3A39EE7", Name: "Standard Panner"}
+	trackComp := parser.Plugin{GUID: "56535444475443747261636B636F6D70", Name: "TrackComp"}
+
+	var metadata []byte
+	metadata = append(metadata, parser.AppVersionSearchTerm...)
+	metadata = append(metadata, make([]byte, 9)...)
+	metadata = append(metadata, projectToken("Cubase")...)
+	metadata = append(metadata, make([]byte, 3)...)
+	metadata = append(metadata, projectToken("Version 13.0.10")...)
+	metadata = append(metadata, make([]byte, 3)...)
+	metadata = append(metadata, projectToken("Oct 10 2023")...)
+	metadata = append(metadata, make([]byte, 7)...)
+	metadata = append(metadata, projectToken("WIN64")...)
+
+	testCases := []struct {
+		name     string
+		contents [][]byte
+		expected []parser.Role
+	}{
+		{
+			// The name of the Standard Panner plugin ends with "Panner" but isn't a panner
+			// marker, so the insert which follows it keeps its role.
+			name: "plugin name containing a marker",
+			contents: [][]byte{
+				projectToken("InsertFolder"),
+				projectPlugin(panner),
+				projectPlugin(trackComp),
+			},
+			expected: []parser.Role{parser.RoleInsert, parser.RoleInsert},
+		},
+		{
+			name: "marker",
+			contents: [][]byte{
+				projectToken("InsertFolder"),
+				projectPlugin(trackComp),
+				projectToken("Panner"),
+				projectPlugin(panner),
+			},
+			expected: []parser.Role{parser.RoleInsert, parser.RoleChannelStrip},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectBytes := slices.Concat(append([][]byte{metadata}, tc.contents...)...)
+
+			reader := parser.NewReader(projectBytes)
+			project, err := reader.GetProjectDetails()
+			require.NoError(t, err)
+
+			roles := make([]parser.Role, 0, len(project.Occurrences))
+			for _, occurrence := range project.Occurrences {
+				roles = append(roles, occurrence.Role)
+			}
+
+			require.Equal(t, tc.expected, roles)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortedPlugins returns the plugins in the counts map provided sorted by name.
+func SortedPlugins(pluginCounts map[Plugin]int) []Plugin {
+	plugins := make([]Plugin, 0, len(pluginCounts))
+	for plugin := range pluginCounts {
+		plugins = append(plugins, plugin)
+	}
+
+	SortPluginsByName(plugins)
+
+	return plugins
+}
+
+// SortPluginsByName sorts the plugins provided by name (ignoring case) and then by GUID.
+func SortPluginsByName(plugins []Plugin) {
+	slices.SortFunc(plugins, func(a, b Plugin) int {
+		return cmp.Or(
+			cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			cmp.Compare(a.GUID, b.GUID),
+		)
+	})
+}
+
+// SortPluginsByGUID sorts the plugins provided by GUID.
+func SortPluginsByGUID(plugins []Plugin) {
+	slices.SortFunc(plugins, func(a, b Plugin) int {
+		return cmp.Compare(a.GUID, b.GUID)
+	})
+}
@@ -0,0 +1,30 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+func TestSortPlugins(t *testing.T) {
+	t.Parallel()
+
+	elephant := parser.Plugin{GUID: "E4B91D8420B74C48A8B10F2DB9CB707E", Name: "Elephant"}
+	eq := parser.Plugin{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"}
+	omnisphere := parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+
+	require.Equal(
+		t,
+		[]parser.Plugin{elephant, eq, omnisphere},
+		parser.SortedPlugins(map[parser.Plugin]int{omnisphere: 1, eq: 2, elephant: 3}),
+	)
+
+	plugins := []parser.Plugin{omnisphere, elephant, eq}
+	parser.SortPluginsByGUID(plugins)
+	require.Equal(t, []parser.Plugin{eq, omnisphere, elephant}, plugins)
+
+	parser.SortPluginsByName(plugins)
+	require.Equal(t, []parser.Plugin{elephant, eq, omnisphere}, plugins)
+}
@@ -0,0 +1,6 @@
+package parser
+
+// Version identifies the behaviour of the parser.  It must be incremented whenever a change is
+// made which alters the details obtained from a project so that any previously cached results
+// are discarded.
+const Version = 7
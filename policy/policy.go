@@ -0,0 +1,191 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var (
+	ErrEmptyPolicy        = errors.New("the config file doesn't define a [policy] section")
+	ErrInvalidNamePattern = errors.New("a denied plugin name pattern in the policy is invalid")
+)
+
+// The rules which a project or plugin may violate.
+const (
+	RuleNotAllowed = "not_allowed" // the plugin GUID isn't in the allowed GUIDs
+	RuleDeniedGUID = "denied_guid" // the plugin GUID is in the denied GUIDs
+	RuleDeniedName = "denied_name" // the plugin name matches a denied name pattern
+	RuleVersion    = "version"     // the Cubase version of the project isn't permitted
+)
+
+// A project or plugin used in a project which violates the policy.
+type Violation struct {
+	Path   string         // path to the project file
+	Plugin *parser.Plugin // plugin which violates the policy or nil for the project itself
+	Rule   string         // rule violated by the project or plugin
+	Detail string         // description of why the project or plugin violates the rule
+}
+
+// Checks the plugins used in projects against the plugin policy in the config.  GUID and name
+// comparisons are case-insensitive.
+type Checker struct {
+	allowedGUIDs       []string
+	deniedGUIDs        []string
+	deniedNamePatterns []namePattern
+	versions           parser.VersionRange
+	ignores            config.Plugins // plugins which don't need to be in the allowed GUIDs
+}
+
+// A denied plugin name pattern along with its lower case form used for matching.
+type namePattern struct {
+	pattern      string
+	lowerPattern string
+}
+
+// ScanConfig returns a copy of the config provided which reports every plugin in every project so
+// that the policy is checked against all of them.  The architecture and version filters only
+// determine what is reported by other commands, and plugin ignores are instead applied by the
+// checker so that a denied plugin can't be hidden from the policy by ignoring it.  Path ignore
+// patterns are still applied.
+func ScanConfig(cfg config.Config) config.Config {
+	cfg.Projects = config.Projects{Report32Bit: true, Report64Bit: true}
+	cfg.Plugins = config.Plugins{}
+
+	return cfg
+}
+
+// NewChecker returns a checker which enforces the policy in the config provided.  Plugins which
+// are ignored in the config (e.g. the plugins bundled with Cubase) don't need to be listed in the
+// allowed GUIDs, but are still reported when they are denied.
+func NewChecker(cfg config.Config) (*Checker, error) {
+	policy := cfg.Policy
+
+	if len(policy.AllowedGUIDs) == 0 && len(policy.DeniedGUIDs) == 0 &&
+		len(policy.DeniedNamePatterns) == 0 && policy.MinVersion == "" && policy.MaxVersion == "" {
+		return nil, ErrEmptyPolicy
+	}
+
+	versions, err := parser.ParseVersionRange(policy.MinVersion, policy.MaxVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Checker{versions: versions, ignores: cfg.Plugins}
+
+	for _, guid := range policy.AllowedGUIDs {
+		c.allowedGUIDs = append(c.allowedGUIDs, strings.ToUpper(guid))
+	}
+
+	for _, guid := range policy.DeniedGUIDs {
+		c.deniedGUIDs = append(c.deniedGUIDs, strings.ToUpper(guid))
+	}
+
+	for _, pattern := range policy.DeniedNamePatterns {
+		lowerPattern := strings.ToLower(pattern)
+		if _, err := path.Match(lowerPattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNamePattern, pattern)
+		}
+
+		c.deniedNamePatterns = append(
+			c.deniedNamePatterns, namePattern{pattern: pattern, lowerPattern: lowerPattern},
+		)
+	}
+
+	return c, nil
+}
+
+// Check returns every violation of the policy by the project provided and the plugins reported
+// for it.  A plugin which breaks several rules produces a violation for each of them.
+func (c *Checker) Check(result scan.Result) []Violation {
+	var violations []Violation
+
+	if violation := c.checkVersion(result); violation != nil {
+		violations = append(violations, *violation)
+	}
+
+	for _, plugin := range result.Plugins {
+		guid := strings.ToUpper(plugin.GUID)
+
+		if len(c.allowedGUIDs) > 0 && !slices.Contains(c.allowedGUIDs, guid) &&
+			!c.isIgnored(plugin) {
+			violations = append(violations, Violation{
+				Path:   result.Path,
+				Plugin: &plugin,
+				Rule:   RuleNotAllowed,
+				Detail: "the plugin is not in the allowed GUIDs",
+			})
+		}
+
+		if slices.Contains(c.deniedGUIDs, guid) {
+			violations = append(violations, Violation{
+				Path:   result.Path,
+				Plugin: &plugin,
+				Rule:   RuleDeniedGUID,
+				Detail: "the plugin is in the denied GUIDs",
+			})
+		}
+
+		name := strings.ToLower(plugin.Name)
+		for _, pattern := range c.deniedNamePatterns {
+			if match, _ := path.Match(pattern.lowerPattern, name); match {
+				violations = append(violations, Violation{
+					Path:   result.Path,
+					Plugin: &plugin,
+					Rule:   RuleDeniedName,
+					Detail: fmt.Sprintf(
+						"the plugin name matches the denied pattern %q", pattern.pattern,
+					),
+				})
+
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+// isIgnored determines whether the plugin provided is ignored in the config, matching GUIDs and
+// names in the same way as the scanner.
+func (c *Checker) isIgnored(plugin parser.Plugin) bool {
+	return slices.Contains(c.ignores.GUIDIgnores, plugin.GUID) ||
+		slices.Contains(c.ignores.NameIgnores, plugin.Name)
+}
+
+// checkVersion returns a violation when the Cubase version of the project provided is outside of
+// the permitted range or can't be determined.
+func (c *Checker) checkVersion(result scan.Result) *Violation {
+	if !c.versions.IsBounded() {
+		return nil
+	}
+
+	metadataVersion := result.Project.Metadata.Version
+
+	version, err := parser.ParseAppVersion(metadataVersion)
+	if err != nil {
+		return &Violation{
+			Path:   result.Path,
+			Rule:   RuleVersion,
+			Detail: fmt.Sprintf("unable to determine the Cubase version from %q", metadataVersion),
+		}
+	}
+
+	if c.versions.Contains(version) {
+		return nil
+	}
+
+	return &Violation{
+		Path: result.Path,
+		Rule: RuleVersion,
+		Detail: fmt.Sprintf(
+			"Cubase %s is outside of the permitted versions (%s)", metadataVersion, c.versions,
+		),
+	}
+}
@@ -0,0 +1,187 @@
+package policy_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/policy"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var (
+	elephant   = parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	hive       = parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+	omnisphere = parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	checker, err := policy.NewChecker(config.Config{
+		Policy: config.Policy{
+			AllowedGUIDs:       []string{elephant.GUID, "d39d5b69d6af42fa1234567868495645"},
+			DeniedGUIDs:        []string{elephant.GUID},
+			DeniedNamePatterns: []string{"HIV*"},
+		},
+	})
+	require.NoError(t, err)
+
+	result := scan.Result{
+		Path:    "Project.cpr",
+		Project: &parser.Project{Metadata: parser.Metadata{Version: "13.0.10"}},
+		Plugins: []parser.Plugin{elephant, hive, omnisphere},
+	}
+
+	violations := checker.Check(result)
+
+	require.Equal(t, []policy.Violation{
+		{
+			Path:   "Project.cpr",
+			Plugin: &elephant,
+			Rule:   policy.RuleDeniedGUID,
+			Detail: "the plugin is in the denied GUIDs",
+		},
+		{
+			Path:   "Project.cpr",
+			Plugin: &hive,
+			Rule:   policy.RuleDeniedName,
+			Detail: `the plugin name matches the denied pattern "HIV*"`,
+		},
+		{
+			Path:   "Project.cpr",
+			Plugin: &omnisphere,
+			Rule:   policy.RuleNotAllowed,
+			Detail: "the plugin is not in the allowed GUIDs",
+		},
+	}, violations)
+}
+
+func TestCheckNoViolations(t *testing.T) {
+	t.Parallel()
+
+	checker, err := policy.NewChecker(
+		config.Config{Policy: config.Policy{DeniedGUIDs: []string{omnisphere.GUID}}},
+	)
+	require.NoError(t, err)
+
+	require.Empty(t, checker.Check(scan.Result{
+		Project: &parser.Project{Metadata: parser.Metadata{Version: "13.0.10"}},
+		Plugins: []parser.Plugin{elephant, hive},
+	}))
+}
+
+func TestCheckVersion(t *testing.T) {
+	t.Parallel()
+
+	checker, err := policy.NewChecker(
+		config.Config{Policy: config.Policy{MinVersion: "9.5", MaxVersion: "12"}},
+	)
+	require.NoError(t, err)
+
+	testCases := map[string]string{
+		"3.1.1":   "Cubase 3.1.1 is outside of the permitted versions (>= 9.5, <= 12)",
+		"9.5.41":  "",
+		"12.0.70": "",
+		"13.0.10": "Cubase 13.0.10 is outside of the permitted versions (>= 9.5, <= 12)",
+		"Unknown": `unable to determine the Cubase version from "Unknown"`,
+	}
+
+	for version, detail := range testCases {
+		violations := checker.Check(scan.Result{
+			Path:    "Project.cpr",
+			Project: &parser.Project{Metadata: parser.Metadata{Version: version}},
+		})
+
+		if detail == "" {
+			require.Empty(t, violations, version)
+			continue
+		}
+
+		require.Equal(t, []policy.Violation{
+			{Path: "Project.cpr", Rule: policy.RuleVersion, Detail: detail},
+		}, violations, version)
+	}
+}
+
+func TestNewCheckerInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := policy.NewChecker(config.Config{})
+	require.ErrorIs(t, err, policy.ErrEmptyPolicy)
+
+	_, err = policy.NewChecker(
+		config.Config{Policy: config.Policy{DeniedNamePatterns: []string{"["}}},
+	)
+	require.ErrorIs(t, err, policy.ErrInvalidNamePattern)
+
+	_, err = policy.NewChecker(
+		config.Config{Policy: config.Policy{MinVersion: "13", MaxVersion: "12"}},
+	)
+	require.ErrorIs(t, err, parser.ErrInvalidVersionRange)
+}
+
+func TestCheckIgnoredPlugins(t *testing.T) {
+	t.Parallel()
+
+	checker, err := policy.NewChecker(config.Config{
+		Plugins: config.Plugins{
+			GUIDIgnores: []string{elephant.GUID},
+			NameIgnores: []string{hive.Name},
+		},
+		Policy: config.Policy{
+			AllowedGUIDs: []string{omnisphere.GUID},
+			DeniedGUIDs:  []string{elephant.GUID},
+		},
+	})
+	require.NoError(t, err)
+
+	// Ignored plugins don't need to be allowed but are still reported when they are denied.
+	require.Equal(t, []policy.Violation{
+		{
+			Path:   "Project.cpr",
+			Plugin: &elephant,
+			Rule:   policy.RuleDeniedGUID,
+			Detail: "the plugin is in the denied GUIDs",
+		},
+	}, checker.Check(scan.Result{
+		Path:    "Project.cpr",
+		Project: &parser.Project{Metadata: parser.Metadata{Version: "13.0.10"}},
+		Plugins: []parser.Plugin{elephant, hive, omnisphere},
+	}))
+}
+
+func TestScanConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{
+		Projects: config.Projects{Report32Bit: true, Report64Bit: false, MinVersion: "14"},
+		Plugins: config.Plugins{
+			GUIDIgnores: []string{omnisphere.GUID},
+			NameIgnores: []string{omnisphere.Name},
+		},
+		Policy: config.Policy{DeniedGUIDs: []string{omnisphere.GUID}},
+	}
+
+	checker, err := policy.NewChecker(cfg)
+	require.NoError(t, err)
+
+	projectPath := filepath.Join("..", "parser", "testdata", "Example Project (Cubase 13).cpr")
+	report, err := scan.NewScanner(policy.ScanConfig(cfg)).
+		Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.Equal(t, []policy.Violation{
+		{
+			Path:   projectPath,
+			Plugin: &omnisphere,
+			Rule:   policy.RuleDeniedGUID,
+			Detail: "the plugin is in the denied GUIDs",
+		},
+	}, checker.Check(report.Results[0]))
+}
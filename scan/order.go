@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+var ErrUnknownOrder = errors.New("the plugin order requested is not supported")
+
+// The order in which the plugins used in each project are reported.
+type Order string
+
+// The orders supported for the plugins used in each project.
+const (
+	OrderAppearance Order = "appearance" // the order plugins first appear in the project
+	OrderName       Order = "name"       // by name (ignoring case) and then by GUID
+	OrderGUID       Order = "guid"       // by GUID
+)
+
+// ParseOrder parses the name of a plugin order.
+func ParseOrder(order string) (Order, error) {
+	switch Order(order) {
+	case OrderAppearance, OrderName, OrderGUID:
+		return Order(order), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownOrder, order)
+	}
+}
+
+// Sort sorts the plugins provided, which must be in the order they first appear in the project,
+// into the order.  Plugins are sorted by name when the order is empty.
+func (o Order) Sort(plugins []parser.Plugin) {
+	switch o {
+	case OrderAppearance:
+	case OrderGUID:
+		parser.SortPluginsByGUID(plugins)
+	default:
+		parser.SortPluginsByName(plugins)
+	}
+}
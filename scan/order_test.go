@@ -0,0 +1,51 @@
+package scan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+func TestParseOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range []scan.Order{scan.OrderAppearance, scan.OrderName, scan.OrderGUID} {
+		parsed, err := scan.ParseOrder(string(order))
+		require.NoError(t, err)
+		require.Equal(t, order, parsed)
+	}
+
+	_, err := scan.ParseOrder("size")
+	require.ErrorIs(t, err, scan.ErrUnknownOrder)
+}
+
+func TestOrderSort(t *testing.T) {
+	t.Parallel()
+
+	hive := parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+	elephant := parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	omnisphere := parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+
+	testCases := []struct {
+		order    scan.Order
+		expected []parser.Plugin
+	}{
+		{order: scan.OrderAppearance, expected: []parser.Plugin{hive, omnisphere, elephant}},
+		{order: scan.OrderName, expected: []parser.Plugin{elephant, hive, omnisphere}},
+		{order: scan.OrderGUID, expected: []parser.Plugin{elephant, omnisphere, hive}},
+		{order: "", expected: []parser.Plugin{elephant, hive, omnisphere}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.order), func(t *testing.T) {
+			t.Parallel()
+
+			plugins := []parser.Plugin{hive, omnisphere, elephant}
+			tc.order.Sort(plugins)
+			require.Equal(t, tc.expected, plugins)
+		})
+	}
+}
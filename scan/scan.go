@@ -0,0 +1,344 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/fgimian/cubase-project-plugins/cache"
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+)
+
+// The operations which may produce a warning while walking project paths.
+const (
+	OpWalk = "walk"
+	OpRead = "read"
+)
+
+// Describes a project which has been scanned along with the plugins that should be reported.
+type Result struct {
+	Path    string          // path to the project file
+	Project *parser.Project // details parsed from the project file
+	Plugins []parser.Plugin // plugins to report after ignores are applied, sorted by the order
+	Is64Bit bool            // whether the project was created on a 64-bit version of Cubase
+}
+
+// Describes a project which could not be parsed.
+type Failure struct {
+	Path string // path to the project file
+	Err  error  // error which occurred while parsing the project
+}
+
+// Describes a path which could not be walked or read while searching for projects.
+type Warning struct {
+	Path string // path which could not be walked or read
+	Op   string // operation which failed
+	Err  error  // error which occurred during the operation
+}
+
+// Contains the outcome of a scan.
+type Report struct {
+	Results  []Result  // projects scanned in the order they were walked
+	Failures []Failure // projects which could not be parsed
+	Warnings []Warning // paths which could not be walked or read
+	Summary  Summary   // plugin usage by project architecture
+}
+
+// Scans directories of Cubase projects and reports the plugins used in each project, applying
+// the path ignore patterns, project architecture and version settings and plugin ignores in the
+// config.
+type Scanner struct {
+	Config   config.Config // configuration which determines the projects and plugins reported
+	Jobs     int           // number of projects to parse concurrently
+	Cache    *cache.Cache  // cache of parsed projects which is used when not nil
+	FailFast bool          // whether to stop scanning when a project can't be parsed
+	Order    Order         // order of the plugins reported for each project (name by default)
+	Role     parser.Role   // only report plugins used in this role when set
+
+	PluginFormat parser.Format // only report plugins of this format when set
+
+	// Optional callbacks which are called as soon as each result, failure or warning is
+	// available, in the order paths were walked.  Returning an error stops the scan.
+	OnResult  func(result Result) error
+	OnFailure func(failure Failure) error
+	OnWarning func(warning Warning) error
+}
+
+// NewScanner returns a new scanner using the config provided which parses as many projects
+// concurrently as there are CPUs available.
+func NewScanner(config config.Config) *Scanner {
+	return &Scanner{Config: config, Jobs: runtime.GOMAXPROCS(0)}
+}
+
+// An item produced while walking project paths.  Each item is either a path which could not be
+// walked or a project which has been read and parsed by a worker.
+type walkItem struct {
+	index   int             // position of the item in the order paths were walked
+	path    string          // path which was walked
+	info    fs.FileInfo     // file information obtained while walking the path
+	warning *Warning        // set when the path could not be walked or read
+	project *parser.Project // set when the project was parsed successfully
+	err     error           // set when the project could not be parsed
+}
+
+// Scan walks each of the project paths provided and parses every project found which isn't
+// excluded by the config.  Projects are read and parsed concurrently (reusing results from the
+// cache when it's set and the project hasn't changed), but results are always delivered to the
+// callbacks from the calling goroutine in the order paths were walked.  The scan stops when the
+// context is cancelled, when a callback returns an error or when a project can't be parsed and
+// FailFast is set.
+func (s *Scanner) Scan(ctx context.Context, projectPaths []string) (*Report, error) {
+	versions, err := parser.ParseVersionRange(
+		s.Config.Projects.MinVersion, s.Config.Projects.MaxVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make(chan walkItem)
+	results := make(chan walkItem)
+
+	go func() {
+		defer close(items)
+		s.walkPaths(ctx, projectPaths, items)
+	}()
+
+	var wg sync.WaitGroup
+	for range max(s.Jobs, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				if item.warning == nil {
+					s.parseWalkItem(&item)
+				}
+
+				select {
+				case results <- item:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	report := &Report{Summary: NewSummary()}
+	report.Summary.Role = s.Role
+
+	// Results arrive in the order workers complete them, so they are buffered until all earlier
+	// results have been delivered.
+	pending := make(map[int]walkItem)
+	next := 0
+
+	for result := range results {
+		pending[result.index] = result
+
+		for {
+			item, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			delete(pending, next)
+			next++
+
+			if err := s.deliver(item, versions, report); err != nil {
+				// Stop walking and drain any remaining results so that all workers may exit.
+				cancel()
+				for range results { //nolint:revive // the channel is intentionally discarded
+				}
+
+				return report, err
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// deliver records the item provided in the report and calls the related callback.  Projects
+// created with a Cubase version outside of the range provided are skipped, unless their version
+// can't be determined.
+func (s *Scanner) deliver(item walkItem, versions parser.VersionRange, report *Report) error {
+	switch {
+	case item.warning != nil:
+		report.Warnings = append(report.Warnings, *item.warning)
+		if s.OnWarning != nil {
+			return s.OnWarning(*item.warning)
+		}
+	case item.err != nil:
+		if s.FailFast {
+			return fmt.Errorf("%s: %w", item.path, item.err)
+		}
+
+		failure := Failure{Path: item.path, Err: item.err}
+		report.Failures = append(report.Failures, failure)
+		if s.OnFailure != nil {
+			return s.OnFailure(failure)
+		}
+	default:
+		is64Bit := item.project.Metadata.Architecture == "WIN64" ||
+			item.project.Metadata.Architecture == "MAC64 LE"
+
+		if is64Bit && !s.Config.Projects.Report64Bit ||
+			!is64Bit && !s.Config.Projects.Report32Bit {
+			return nil
+		}
+
+		if version, err := parser.ParseAppVersion(item.project.Metadata.Version); err == nil &&
+			!versions.Contains(version) {
+			return nil
+		}
+
+		var displayPlugins []parser.Plugin
+
+		var roles map[parser.Plugin][]parser.Role
+		if s.Role != "" {
+			roles = item.project.Roles()
+		}
+
+		for _, plugin := range item.project.Plugins {
+			if slices.Contains(s.Config.Plugins.GUIDIgnores, plugin.GUID) ||
+				slices.Contains(s.Config.Plugins.NameIgnores, plugin.Name) {
+				continue
+			}
+
+			if s.Role != "" && !slices.Contains(roles[plugin], s.Role) {
+				continue
+			}
+
+			if s.PluginFormat != "" && plugin.Format() != s.PluginFormat {
+				continue
+			}
+
+			displayPlugins = append(displayPlugins, plugin)
+		}
+
+		s.Order.Sort(displayPlugins)
+
+		result := Result{
+			Path:    item.path,
+			Project: item.project,
+			Plugins: displayPlugins,
+			Is64Bit: is64Bit,
+		}
+
+		report.Results = append(report.Results, result)
+		report.Summary.Add(result)
+		if s.OnResult != nil {
+			return s.OnResult(result)
+		}
+	}
+
+	return nil
+}
+
+// walkPaths walks each of the project paths provided and sends an item for each project found
+// and each path which could not be walked, skipping any excluded by the path ignore patterns.
+func (s *Scanner) walkPaths(ctx context.Context, projectPaths []string, items chan<- walkItem) {
+	index := 0
+
+	for _, projectPath := range projectPaths {
+		err := filepath.Walk(
+			projectPath,
+			func(path string, info fs.FileInfo, err error) error {
+				item := walkItem{index: index, path: path, info: info}
+
+				if err != nil {
+					// Paths which are ignored are never reported, even when they can't be walked.
+					if s.IsPathIgnored(path) {
+						if info != nil && info.IsDir() {
+							return filepath.SkipDir
+						}
+
+						return nil
+					}
+
+					item.warning = &Warning{Path: path, Op: OpWalk, Err: err}
+				} else if filepath.Ext(path) != ".cpr" || s.IsPathIgnored(path) {
+					return nil
+				}
+
+				select {
+				case items <- item:
+					index++
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// IsPathIgnored determines whether the path provided matches any of the path ignore patterns in
+// the config.
+func (s *Scanner) IsPathIgnored(path string) bool {
+	for _, pathIgnorePattern := range s.Config.PathIgnorePatterns {
+		match, err := doublestar.Match(
+			filepath.ToSlash(pathIgnorePattern),
+			filepath.ToSlash(path),
+		)
+		if err == nil && match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseWalkItem reads and parses the project at the path of the item provided and updates the
+// item with the outcome.  The cache is used to avoid reading and parsing unchanged projects when
+// it isn't nil.
+func (s *Scanner) parseWalkItem(item *walkItem) {
+	if s.Cache != nil {
+		if project, ok := s.Cache.Get(item.path, item.info); ok {
+			item.project = project
+			return
+		}
+	}
+
+	projectBytes, err := os.ReadFile(item.path)
+	if err != nil {
+		item.warning = &Warning{Path: item.path, Op: OpRead, Err: err}
+		return
+	}
+
+	var hash string
+	if s.Cache != nil {
+		hash = cache.Hash(projectBytes)
+		if project, ok := s.Cache.GetByHash(item.path, item.info, hash); ok {
+			item.project = project
+			return
+		}
+	}
+
+	reader := parser.NewReader(projectBytes)
+	item.project, item.err = reader.GetProjectDetails()
+
+	if s.Cache != nil && item.err == nil {
+		s.Cache.Put(item.path, item.info, hash, item.project)
+	}
+}
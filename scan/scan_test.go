@@ -0,0 +1,354 @@
+package scan_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+var testDataPath = filepath.Join("..", "parser", "testdata")
+
+func defaultConfig() config.Config {
+	return config.Config{
+		Projects: config.Projects{
+			Report32Bit: true,
+			Report64Bit: true,
+		},
+	}
+}
+
+func resultPaths(results []scan.Result) []string {
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		paths = append(paths, filepath.Base(result.Path))
+	}
+
+	return paths
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	scanner := scan.NewScanner(defaultConfig())
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 14)
+	require.Len(t, report.Failures, 8)
+	require.Empty(t, report.Warnings)
+
+	require.Equal(t, "Example Project (Cubase 11).cpr", filepath.Base(report.Results[0].Path))
+	require.Equal(
+		t,
+		"Truncated Project (Application).cpr",
+		filepath.Base(report.Failures[0].Path),
+	)
+	require.ErrorIs(t, report.Failures[0].Err, parser.ErrNoApplication)
+
+	elephant := parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}
+	require.Equal(t, 13, report.Summary.PluginCounts[elephant])
+	require.Equal(t, 5, report.Summary.PluginCounts32[elephant])
+	require.Equal(t, 8, report.Summary.PluginCounts64[elephant])
+	require.Len(t, report.Summary.PluginProjects[elephant], 13)
+}
+
+func TestScanDeterministicOrder(t *testing.T) {
+	t.Parallel()
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.Jobs = 1
+	expected, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	for _, jobs := range []int{2, 4, 16} {
+		scanner.Jobs = jobs
+		report, err := scanner.Scan(context.Background(), []string{testDataPath})
+		require.NoError(t, err)
+		require.Equal(t, resultPaths(expected.Results), resultPaths(report.Results))
+	}
+}
+
+func TestScanConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig()
+	cfg.PathIgnorePatterns = []string{"**/Truncated*.cpr"}
+	cfg.Projects.Report32Bit = false
+	cfg.Plugins.GUIDIgnores = []string{"1C3A662167D347A99F7D797EA4911CDB"}
+	cfg.Plugins.NameIgnores = []string{"Hive"}
+
+	scanner := scan.NewScanner(cfg)
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 8)
+	require.Empty(t, report.Failures)
+
+	for _, result := range report.Results {
+		require.True(t, result.Is64Bit)
+
+		for _, plugin := range result.Plugins {
+			require.NotEqual(t, "Elephant", plugin.Name)
+			require.NotEqual(t, "Hive", plugin.Name)
+		}
+	}
+
+	require.Empty(t, report.Summary.PluginCounts32)
+}
+
+func TestScanCallbacks(t *testing.T) {
+	t.Parallel()
+
+	var results, failures int
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.OnResult = func(_ scan.Result) error {
+		results++
+		return nil
+	}
+	scanner.OnFailure = func(_ scan.Failure) error {
+		failures++
+		return nil
+	}
+
+	_, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	require.Equal(t, 14, results)
+	require.Equal(t, 8, failures)
+}
+
+func TestScanCallbackError(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.OnResult = func(_ scan.Result) error {
+		return errStop
+	}
+
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.ErrorIs(t, err, errStop)
+	require.Len(t, report.Results, 1)
+}
+
+func TestScanFailFast(t *testing.T) {
+	t.Parallel()
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.FailFast = true
+
+	_, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.ErrorIs(t, err, parser.ErrNoApplication)
+}
+
+func TestScanCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	scanner := scan.NewScanner(defaultConfig())
+	_, err := scanner.Scan(ctx, []string{testDataPath})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScanWarnings(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "Directory.cpr"), 0o755))
+
+	missingPath := filepath.Join(dir, "Missing")
+
+	scanner := scan.NewScanner(defaultConfig())
+	report, err := scanner.Scan(context.Background(), []string{dir, missingPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Warnings, 2)
+	require.Equal(t, scan.OpRead, report.Warnings[0].Op)
+	require.Equal(t, filepath.Join(dir, "Directory.cpr"), report.Warnings[0].Path)
+	require.Equal(t, scan.OpWalk, report.Warnings[1].Op)
+	require.ErrorIs(t, report.Warnings[1].Err, os.ErrNotExist)
+}
+
+func TestScanWarningsIgnored(t *testing.T) {
+	t.Parallel()
+
+	missingPath := filepath.Join(t.TempDir(), "Missing")
+
+	cfg := defaultConfig()
+	cfg.PathIgnorePatterns = []string{"**/Missing"}
+
+	scanner := scan.NewScanner(cfg)
+	report, err := scanner.Scan(context.Background(), []string{testDataPath, missingPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 14)
+	require.Empty(t, report.Warnings)
+}
+
+func TestScanVersionRange(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig()
+	cfg.Projects.MinVersion = "6.5"
+	cfg.Projects.MaxVersion = "11"
+
+	scanner := scan.NewScanner(cfg)
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"Example Project (Cubase 11).cpr",
+		"Example Project (Cubase 6.5 32-bit).cpr",
+		"Example Project (Cubase 6.5 64-bit).cpr",
+		"Example Project (Cubase 7 32-bit).cpr",
+		"Example Project (Cubase 7 64-bit).cpr",
+		"Example Project (Cubase 8.5 32-bit).cpr",
+		"Example Project (Cubase 8.5 64-bit).cpr",
+		"Example Project (Cubase 9.5).cpr",
+	}, resultPaths(report.Results))
+}
+
+func TestScanInvalidVersionRange(t *testing.T) {
+	t.Parallel()
+
+	cfg := defaultConfig()
+	cfg.Projects.MinVersion = "latest"
+
+	scanner := scan.NewScanner(cfg)
+	_, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.ErrorIs(t, err, parser.ErrInvalidAppVersion)
+}
+
+func TestScanOrder(t *testing.T) {
+	t.Parallel()
+
+	projectPath := filepath.Join(testDataPath, "Example Project (Cubase 13).cpr")
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.Order = scan.OrderAppearance
+	report, err := scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.Equal(t, report.Results[0].Project.Plugins, report.Results[0].Plugins)
+
+	scanner.Order = scan.OrderName
+	report, err = scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.Equal(t, "ArtsAcousticReverb", report.Results[0].Plugins[0].Name)
+	require.Equal(t, "TrackComp", report.Results[0].Plugins[11].Name)
+}
+
+func TestScanRole(t *testing.T) {
+	t.Parallel()
+
+	projectPath := filepath.Join(testDataPath, "Example Project (Cubase 13).cpr")
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.Role = parser.RoleInstrument
+	report, err := scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	hive := parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}
+	omnisphere := parser.Plugin{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"}
+	sylenth := parser.Plugin{GUID: "56535473796C3173796C656E74683100", Name: "Sylenth1"}
+
+	require.Len(t, report.Results, 1)
+	require.Equal(t, []parser.Plugin{hive, omnisphere, sylenth}, report.Results[0].Plugins)
+	require.Equal(
+		t,
+		map[parser.Role]map[parser.Plugin]int{
+			parser.RoleInstrument: {hive: 1, omnisphere: 1, sylenth: 1},
+		},
+		report.Summary.RoleCounts,
+	)
+}
+
+func TestScanRoleSummary(t *testing.T) {
+	t.Parallel()
+
+	projectPath := filepath.Join(testDataPath, "Example Project (Cubase 13).cpr")
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.Role = parser.RoleInsert
+	report, err := scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.NotEmpty(t, report.Summary.RoleCounts[parser.RoleInsert])
+	require.Len(t, report.Summary.RoleCounts, 1)
+	require.Len(t, report.Summary.RoleProjects, 1)
+}
+
+func TestSummarySortedPluginCounts(t *testing.T) {
+	t.Parallel()
+
+	scanner := scan.NewScanner(defaultConfig())
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	counts := report.Summary.SortedPluginCounts()
+	require.Len(t, counts, len(report.Summary.PluginCounts))
+	require.True(t, slices.IsSortedFunc(counts, func(a, b scan.PluginCount) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}))
+	require.Contains(t, counts, scan.PluginCount{
+		GUID:    "1C3A662167D347A99F7D797EA4911CDB",
+		Name:    "Elephant",
+		Format:  parser.FormatVST3,
+		Count32: 5,
+		Count64: 8,
+		Count:   13,
+	})
+}
+
+func TestScanPluginFormat(t *testing.T) {
+	t.Parallel()
+
+	projectPath := filepath.Join(testDataPath, "Example Project (Cubase 13).cpr")
+
+	scanner := scan.NewScanner(defaultConfig())
+	scanner.PluginFormat = parser.FormatVST2
+	report, err := scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.Equal(
+		t,
+		[]parser.Plugin{
+			{GUID: "565354414152626172747361636F7573", Name: "ArtsAcousticReverb"},
+			{GUID: "56535455564852757632326872000000", Name: "Lin Dither"},
+			{GUID: "565354416D62726F6D6E697370686572", Name: "Omnisphere"},
+			{GUID: "56535473796C3173796C656E74683100", Name: "Sylenth1"},
+			{GUID: "56535444475443747261636B636F6D70", Name: "TrackComp"},
+		},
+		report.Results[0].Plugins,
+	)
+
+	scanner.PluginFormat = parser.FormatVST3
+	report, err = scanner.Scan(context.Background(), []string{projectPath})
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 1)
+	require.NotEmpty(t, report.Results[0].Plugins)
+	for _, plugin := range report.Results[0].Plugins {
+		require.Equal(t, parser.FormatVST3, plugin.Format())
+	}
+}
@@ -0,0 +1,98 @@
+package scan
+
+import "github.com/fgimian/cubase-project-plugins/parser"
+
+// Aggregates the number of projects each plugin was used in, along with the paths of those
+// projects, by project architecture.
+type Summary struct {
+	PluginCounts     map[parser.Plugin]int      // plugin usage across all projects
+	PluginCounts32   map[parser.Plugin]int      // plugin usage across 32-bit projects
+	PluginCounts64   map[parser.Plugin]int      // plugin usage across 64-bit projects
+	PluginProjects   map[parser.Plugin][]string // paths of all projects using each plugin
+	PluginProjects32 map[parser.Plugin][]string // paths of 32-bit projects using each plugin
+	PluginProjects64 map[parser.Plugin][]string // paths of 64-bit projects using each plugin
+
+	// Plugin usage across all projects and the paths of the projects using each plugin by the
+	// role of the plugin in each project.
+	RoleCounts   map[parser.Role]map[parser.Plugin]int
+	RoleProjects map[parser.Role]map[parser.Plugin][]string
+
+	Role parser.Role // only record usage by role for this role when set
+}
+
+// A plugin along with the number of projects it was used in by project architecture.
+type PluginCount struct {
+	GUID    string        `json:"guid"`         // globally unique identifier for the plugin
+	Name    string        `json:"name"`         // name of the plugin
+	Format  parser.Format `json:"format"`       // format of the plugin (vst2 or vst3)
+	Count32 int           `json:"count_32_bit"` // number of 32-bit projects using the plugin
+	Count64 int           `json:"count_64_bit"` // number of 64-bit projects using the plugin
+	Count   int           `json:"count"`        // number of projects using the plugin
+}
+
+// NewSummary returns an empty summary.
+func NewSummary() Summary {
+	return Summary{
+		PluginCounts:     make(map[parser.Plugin]int),
+		PluginCounts32:   make(map[parser.Plugin]int),
+		PluginCounts64:   make(map[parser.Plugin]int),
+		PluginProjects:   make(map[parser.Plugin][]string),
+		PluginProjects32: make(map[parser.Plugin][]string),
+		PluginProjects64: make(map[parser.Plugin][]string),
+		RoleCounts:       make(map[parser.Role]map[parser.Plugin]int),
+		RoleProjects:     make(map[parser.Role]map[parser.Plugin][]string),
+	}
+}
+
+// Add records the plugins reported for a project in the summary.
+func (s *Summary) Add(result Result) {
+	var roles map[parser.Plugin][]parser.Role
+	if result.Project != nil {
+		roles = result.Project.Roles()
+	}
+
+	for _, plugin := range result.Plugins {
+		s.PluginCounts[plugin]++
+		s.PluginProjects[plugin] = append(s.PluginProjects[plugin], result.Path)
+		if result.Is64Bit {
+			s.PluginCounts64[plugin]++
+			s.PluginProjects64[plugin] = append(s.PluginProjects64[plugin], result.Path)
+		} else {
+			s.PluginCounts32[plugin]++
+			s.PluginProjects32[plugin] = append(s.PluginProjects32[plugin], result.Path)
+		}
+
+		for _, role := range roles[plugin] {
+			if s.Role != "" && role != s.Role {
+				continue
+			}
+
+			if s.RoleCounts[role] == nil {
+				s.RoleCounts[role] = make(map[parser.Plugin]int)
+				s.RoleProjects[role] = make(map[parser.Plugin][]string)
+			}
+
+			s.RoleCounts[role][plugin]++
+			s.RoleProjects[role][plugin] = append(s.RoleProjects[role][plugin], result.Path)
+		}
+	}
+}
+
+// SortedPluginCounts returns the usage of every plugin in the summary sorted by name.
+func (s *Summary) SortedPluginCounts() []PluginCount {
+	plugins := parser.SortedPlugins(s.PluginCounts)
+
+	counts := make([]PluginCount, 0, len(plugins))
+	for _, plugin := range plugins {
+		counts = append(counts, PluginCount{
+			GUID:    plugin.GUID,
+			Name:    plugin.Name,
+			Format:  plugin.Format(),
+			Count32: s.PluginCounts32[plugin],
+			Count64: s.PluginCounts64[plugin],
+			Count:   s.PluginCounts[plugin],
+		})
+	}
+
+	return counts
+}
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/fgimian/cubase-project-plugins/diff"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// A project which exists in both snapshots but whose plugins or metadata changed.
+type ProjectChange struct {
+	Path string           `json:"path"` // path to the project file
+	Diff diff.ProjectDiff `json:"diff"` // differences between the old and new project
+}
+
+// A plugin whose number of projects differs between two snapshots.  Plugins are compared by
+// GUID, so the name is taken from the new snapshot when the plugin exists in both.
+type UsageChange struct {
+	GUID     string        `json:"guid"`      // globally unique identifier for the plugin
+	Name     string        `json:"name"`      // name of the plugin
+	Format   parser.Format `json:"format"`    // format of the plugin
+	OldCount int           `json:"old_count"` // projects using the plugin in the old snapshot
+	NewCount int           `json:"new_count"` // projects using the plugin in the new snapshot
+}
+
+// Delta returns the change in the number of projects using the plugin.
+func (c UsageChange) Delta() int {
+	return c.NewCount - c.OldCount
+}
+
+// The differences between two snapshots.
+type Comparison struct {
+	AddedProjects   []Project       `json:"added_projects"`   // projects only in the new snapshot
+	RemovedProjects []Project       `json:"removed_projects"` // projects only in the old snapshot
+	ChangedProjects []ProjectChange `json:"changed_projects"` // projects which changed
+	UsageChanges    []UsageChange   `json:"usage_changes"`    // plugins whose usage changed
+}
+
+// HasChanges determines whether the snapshots differ in any way.
+func (c *Comparison) HasChanges() bool {
+	return len(c.AddedProjects) > 0 || len(c.RemovedProjects) > 0 ||
+		len(c.ChangedProjects) > 0 || len(c.UsageChanges) > 0
+}
+
+// Compare compares an old and new snapshot.  Projects are matched by path and listed in path
+// order, while usage changes are sorted by the size of the change with the largest first.
+func Compare(oldSnapshot, newSnapshot *Snapshot) Comparison {
+	c := Comparison{
+		AddedProjects:   []Project{},
+		RemovedProjects: []Project{},
+		ChangedProjects: []ProjectChange{},
+		UsageChanges:    []UsageChange{},
+	}
+
+	oldProjects := projectsByPath(oldSnapshot.Projects)
+	newProjects := projectsByPath(newSnapshot.Projects)
+
+	for path, oldProject := range oldProjects {
+		newProject, ok := newProjects[path]
+		if !ok {
+			c.RemovedProjects = append(c.RemovedProjects, oldProject)
+			continue
+		}
+
+		projectDiff := diff.Projects(oldProject.parserProject(), newProject.parserProject())
+		if projectDiff.HasChanges() {
+			c.ChangedProjects = append(
+				c.ChangedProjects, ProjectChange{Path: path, Diff: projectDiff},
+			)
+		}
+	}
+
+	for path, newProject := range newProjects {
+		if _, ok := oldProjects[path]; !ok {
+			c.AddedProjects = append(c.AddedProjects, newProject)
+		}
+	}
+
+	sortByPath := func(a, b Project) int { return cmp.Compare(a.Path, b.Path) }
+	slices.SortFunc(c.AddedProjects, sortByPath)
+	slices.SortFunc(c.RemovedProjects, sortByPath)
+	slices.SortFunc(c.ChangedProjects, func(a, b ProjectChange) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	c.UsageChanges = usageChanges(oldSnapshot.Plugins, newSnapshot.Plugins)
+
+	return c
+}
+
+// usageChanges returns the plugins whose number of projects differs between the old and new
+// plugin counts provided.
+func usageChanges(oldPlugins, newPlugins []scan.PluginCount) []UsageChange {
+	changes := make(map[string]*UsageChange)
+
+	for _, plugin := range oldPlugins {
+		change, ok := changes[plugin.GUID]
+		if !ok {
+			change = &UsageChange{GUID: plugin.GUID, Name: plugin.Name, Format: plugin.Format}
+			changes[plugin.GUID] = change
+		}
+
+		change.OldCount += plugin.Count
+	}
+
+	for _, plugin := range newPlugins {
+		change, ok := changes[plugin.GUID]
+		if !ok {
+			change = &UsageChange{GUID: plugin.GUID}
+			changes[plugin.GUID] = change
+		}
+
+		change.Name = plugin.Name
+		change.Format = plugin.Format
+		change.NewCount += plugin.Count
+	}
+
+	usage := []UsageChange{}
+	for _, change := range changes {
+		if change.OldCount != change.NewCount {
+			usage = append(usage, *change)
+		}
+	}
+
+	slices.SortFunc(usage, func(a, b UsageChange) int {
+		return cmp.Or(
+			cmp.Compare(abs(b.Delta()), abs(a.Delta())),
+			cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+			cmp.Compare(a.GUID, b.GUID),
+		)
+	})
+
+	return usage
+}
+
+// parserProject returns the project in the form used by the parser so that it may be compared.
+func (p Project) parserProject() *parser.Project {
+	plugins := make([]parser.Plugin, 0, len(p.Plugins))
+	for _, plugin := range p.Plugins {
+		plugins = append(plugins, plugin.Plugin())
+	}
+
+	return &parser.Project{Metadata: p.Metadata, Plugins: plugins}
+}
+
+func projectsByPath(projects []Project) map[string]Project {
+	byPath := make(map[string]Project, len(projects))
+	for _, project := range projects {
+		byPath[project.Path] = project
+	}
+
+	return byPath
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,92 @@
+package snapshot_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+	"github.com/fgimian/cubase-project-plugins/snapshot"
+)
+
+var (
+	elephant = parser.Plugin{GUID: "1C3A662167D347A99F7D797EA4911CDB", Name: "Elephant"}.Info()
+	hive     = parser.Plugin{GUID: "D39D5B69D6AF42FA1234567868495645", Name: "Hive"}.Info()
+	eq       = parser.Plugin{GUID: "297BA567D83144E1AE921DEF07B41156", Name: "EQ"}.Info()
+	metadata = parser.Metadata{Application: "Cubase", Version: "13.0.10", Architecture: "WIN64"}
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	oldSnapshot := &snapshot.Snapshot{
+		Projects: []snapshot.Project{
+			{Path: "Removed.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{elephant}},
+			{Path: "Changed.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{elephant, hive}},
+			{Path: "Same.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{hive}},
+		},
+		Plugins: []scan.PluginCount{
+			{GUID: elephant.GUID, Name: elephant.Name, Format: elephant.Format, Count: 2},
+			{GUID: hive.GUID, Name: hive.Name, Format: hive.Format, Count: 2},
+		},
+	}
+	newSnapshot := &snapshot.Snapshot{
+		Projects: []snapshot.Project{
+			{Path: "Added.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{eq, hive}},
+			{Path: "Changed.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{eq, hive}},
+			{Path: "Same.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{hive}},
+		},
+		Plugins: []scan.PluginCount{
+			{GUID: eq.GUID, Name: eq.Name, Format: eq.Format, Count: 2},
+			{GUID: hive.GUID, Name: hive.Name, Format: hive.Format, Count: 3},
+		},
+	}
+
+	c := snapshot.Compare(oldSnapshot, newSnapshot)
+
+	require.True(t, c.HasChanges())
+	require.Equal(t, []snapshot.Project{newSnapshot.Projects[0]}, c.AddedProjects)
+	require.Equal(t, []snapshot.Project{oldSnapshot.Projects[0]}, c.RemovedProjects)
+
+	require.Len(t, c.ChangedProjects, 1)
+	require.Equal(t, "Changed.cpr", c.ChangedProjects[0].Path)
+	require.Equal(t, []parser.PluginInfo{eq}, c.ChangedProjects[0].Diff.Added)
+	require.Equal(t, []parser.PluginInfo{elephant}, c.ChangedProjects[0].Diff.Removed)
+
+	require.Equal(t, []snapshot.UsageChange{
+		{
+			GUID:     elephant.GUID,
+			Name:     elephant.Name,
+			Format:   elephant.Format,
+			OldCount: 2,
+			NewCount: 0,
+		},
+		{GUID: eq.GUID, Name: eq.Name, Format: eq.Format, OldCount: 0, NewCount: 2},
+		{GUID: hive.GUID, Name: hive.Name, Format: hive.Format, OldCount: 2, NewCount: 3},
+	}, c.UsageChanges)
+	require.Equal(t, -2, c.UsageChanges[0].Delta())
+}
+
+func TestCompareIdentical(t *testing.T) {
+	t.Parallel()
+
+	s := &snapshot.Snapshot{
+		Projects: []snapshot.Project{
+			{Path: "Same.cpr", Metadata: metadata, Plugins: []parser.PluginInfo{hive}},
+		},
+		Plugins: []scan.PluginCount{
+			{GUID: hive.GUID, Name: hive.Name, Format: hive.Format, Count: 1},
+		},
+	}
+
+	c := snapshot.Compare(s, s)
+
+	require.False(t, c.HasChanges())
+	require.Equal(t, snapshot.Comparison{
+		AddedProjects:   []snapshot.Project{},
+		RemovedProjects: []snapshot.Project{},
+		ChangedProjects: []snapshot.ProjectChange{},
+		UsageChanges:    []snapshot.UsageChange{},
+	}, c)
+}
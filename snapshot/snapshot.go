@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+)
+
+// Version is the version of the snapshot file structure.  It must be incremented whenever a field
+// is removed, renamed or has its meaning changed.
+const Version = 1
+
+var (
+	ErrReadSnapshot    = errors.New("unable to read the snapshot file")
+	ErrParseSnapshot   = errors.New("unable to parse the snapshot file")
+	ErrSnapshotVersion = errors.New("the snapshot file was created by an unsupported version")
+	ErrSaveSnapshot    = errors.New("unable to save the snapshot file")
+)
+
+// A project along with the plugins reported for it at the time of the snapshot.
+type Project struct {
+	Path     string              `json:"path"`     // path to the project file
+	Metadata parser.Metadata     `json:"metadata"` // details about the Cubase version used
+	Plugins  []parser.PluginInfo `json:"plugins"`  // plugins used after ignores are applied
+}
+
+// A project which could not be parsed at the time of the snapshot.
+type Failure struct {
+	Path  string `json:"path"`  // path to the project file
+	Error string `json:"error"` // description of the error which occurred
+}
+
+// The complete result of a scan persisted so that it may be compared with later scans.
+type Snapshot struct {
+	Version   int                `json:"snapshot_version"` // version of the file structure
+	CreatedAt time.Time          `json:"created_at"`       // time the scan was performed
+	Paths     []string           `json:"paths"`            // project paths which were scanned
+	Projects  []Project          `json:"projects"`         // all projects scanned successfully
+	Plugins   []scan.PluginCount `json:"plugins"`          // plugin usage sorted by name
+	Failures  []Failure          `json:"failures"`         // projects which could not be parsed
+}
+
+// New creates a snapshot of the scan report provided.
+func New(report *scan.Report, paths []string, createdAt time.Time) *Snapshot {
+	s := &Snapshot{
+		Version:   Version,
+		CreatedAt: createdAt,
+		Paths:     paths,
+		Projects:  make([]Project, 0, len(report.Results)),
+		Plugins:   report.Summary.SortedPluginCounts(),
+		Failures:  make([]Failure, 0, len(report.Failures)),
+	}
+
+	for _, result := range report.Results {
+		plugins := make([]parser.PluginInfo, 0, len(result.Plugins))
+		for _, plugin := range result.Plugins {
+			plugins = append(plugins, plugin.Info())
+		}
+
+		s.Projects = append(s.Projects, Project{
+			Path:     result.Path,
+			Metadata: result.Project.Metadata,
+			Plugins:  plugins,
+		})
+	}
+
+	for _, failure := range report.Failures {
+		s.Failures = append(s.Failures, Failure{Path: failure.Path, Error: failure.Err.Error()})
+	}
+
+	return s
+}
+
+// Load loads the snapshot file at the path provided.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrReadSnapshot, err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrParseSnapshot, path, err)
+	}
+
+	if s.Version != Version {
+		return nil, fmt.Errorf("%w: %s (version %d)", ErrSnapshotVersion, path, s.Version)
+	}
+
+	// Snapshots saved before plugin formats were detected don't include them, so they're
+	// detected from the GUID of each plugin instead.
+	for i := range s.Projects {
+		for j, plugin := range s.Projects[i].Plugins {
+			s.Projects[i].Plugins[j].Format = plugin.Plugin().Format()
+		}
+	}
+
+	for i, plugin := range s.Plugins {
+		s.Plugins[i].Format = parser.Plugin{GUID: plugin.GUID, Name: plugin.Name}.Format()
+	}
+
+	return &s, nil
+}
+
+// Save writes the snapshot to the path provided.
+func (s *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSaveSnapshot, err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%w: %w", ErrSaveSnapshot, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package snapshot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/fgimian/cubase-project-plugins/config"
+	"github.com/fgimian/cubase-project-plugins/parser"
+	"github.com/fgimian/cubase-project-plugins/scan"
+	"github.com/fgimian/cubase-project-plugins/snapshot"
+)
+
+var testDataPath = filepath.Join("..", "parser", "testdata")
+
+func TestNewSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	scanner := scan.NewScanner(config.Config{
+		Projects: config.Projects{Report32Bit: true, Report64Bit: true},
+	})
+	report, err := scanner.Scan(context.Background(), []string{testDataPath})
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	s := snapshot.New(report, []string{testDataPath}, createdAt)
+
+	require.Equal(t, snapshot.Version, s.Version)
+	require.Len(t, s.Projects, 14)
+	require.Len(t, s.Failures, 8)
+	require.Contains(t, s.Plugins, scan.PluginCount{
+		GUID:    "1C3A662167D347A99F7D797EA4911CDB",
+		Name:    "Elephant",
+		Format:  parser.FormatVST3,
+		Count32: 5,
+		Count64: 8,
+		Count:   13,
+	})
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, s.Save(path))
+
+	loaded, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, s, loaded)
+}
+
+func TestLoadWithoutFormats(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	data := `{
+		"snapshot_version": 1,
+		"projects": [
+			{
+				"path": "Project.cpr",
+				"plugins": [{"guid": "565354416D62726F6D6E697370686572", "name": "Omnisphere"}]
+			}
+		],
+		"plugins": [{"guid": "1C3A662167D347A99F7D797EA4911CDB", "name": "Elephant", "count": 1}]
+	}`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	s, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, parser.FormatVST2, s.Projects[0].Plugins[0].Format)
+	require.Equal(t, parser.FormatVST3, s.Plugins[0].Format)
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"snapshot_version": 999}`), 0o644))
+
+	_, err := snapshot.Load(path)
+	require.ErrorIs(t, err, snapshot.ErrSnapshotVersion)
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := snapshot.Load(path)
+	require.ErrorIs(t, err, snapshot.ErrParseSnapshot)
+
+	_, err = snapshot.Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, snapshot.ErrReadSnapshot)
+}